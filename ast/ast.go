@@ -0,0 +1,47 @@
+package ast
+
+import "github.com/Sntree2mi8/gogqllexer"
+
+type Node interface {
+	GetPosition() gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#Document
+type Document struct {
+	Definitions []Definition
+	Position    gogqllexer.Position
+}
+
+func (d *Document) GetPosition() gogqllexer.Position { return d.Position }
+
+// https://spec.graphql.org/October2021/#Definition
+type Definition interface {
+	Node
+	isDefinition()
+}
+
+// https://spec.graphql.org/October2021/#Name
+type Name struct {
+	Value    string
+	Position gogqllexer.Position
+}
+
+func (n *Name) GetPosition() gogqllexer.Position { return n.Position }
+
+// https://spec.graphql.org/October2021/#Directive
+type Directive struct {
+	Name      *Name
+	Arguments []*Argument
+	Position  gogqllexer.Position
+}
+
+func (d *Directive) GetPosition() gogqllexer.Position { return d.Position }
+
+// https://spec.graphql.org/October2021/#Argument
+type Argument struct {
+	Name     *Name
+	Value    Value
+	Position gogqllexer.Position
+}
+
+func (a *Argument) GetPosition() gogqllexer.Position { return a.Position }
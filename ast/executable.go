@@ -0,0 +1,95 @@
+package ast
+
+import "github.com/Sntree2mi8/gogqllexer"
+
+// https://spec.graphql.org/October2021/#OperationType
+type OperationType string
+
+const (
+	Query        OperationType = "query"
+	Mutation     OperationType = "mutation"
+	Subscription OperationType = "subscription"
+)
+
+// https://spec.graphql.org/October2021/#OperationDefinition
+type OperationDefinition struct {
+	Operation           OperationType
+	Name                *Name
+	VariableDefinitions []*VariableDefinition
+	Directives          []*Directive
+	SelectionSet        *SelectionSet
+	Position            gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#FragmentDefinition
+type FragmentDefinition struct {
+	Name          *Name
+	TypeCondition *NamedType
+	Directives    []*Directive
+	SelectionSet  *SelectionSet
+	Position      gogqllexer.Position
+}
+
+func (d *OperationDefinition) GetPosition() gogqllexer.Position { return d.Position }
+func (d *FragmentDefinition) GetPosition() gogqllexer.Position  { return d.Position }
+
+func (*OperationDefinition) isDefinition() {}
+func (*FragmentDefinition) isDefinition()  {}
+
+// https://spec.graphql.org/October2021/#VariableDefinition
+type VariableDefinition struct {
+	Variable     *Variable
+	Type         Type
+	DefaultValue Value
+	Directives   []*Directive
+	Position     gogqllexer.Position
+}
+
+func (d *VariableDefinition) GetPosition() gogqllexer.Position { return d.Position }
+
+// https://spec.graphql.org/October2021/#SelectionSet
+type SelectionSet struct {
+	Selections []Selection
+	Position   gogqllexer.Position
+}
+
+func (s *SelectionSet) GetPosition() gogqllexer.Position { return s.Position }
+
+// https://spec.graphql.org/October2021/#Selection
+type Selection interface {
+	Node
+	isSelection()
+}
+
+// https://spec.graphql.org/October2021/#Field
+type Field struct {
+	Alias        *Name
+	Name         *Name
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet *SelectionSet
+	Position     gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#FragmentSpread
+type FragmentSpread struct {
+	Name       *Name
+	Directives []*Directive
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InlineFragment
+type InlineFragment struct {
+	TypeCondition *NamedType
+	Directives    []*Directive
+	SelectionSet  *SelectionSet
+	Position      gogqllexer.Position
+}
+
+func (f *Field) GetPosition() gogqllexer.Position          { return f.Position }
+func (f *FragmentSpread) GetPosition() gogqllexer.Position { return f.Position }
+func (f *InlineFragment) GetPosition() gogqllexer.Position { return f.Position }
+
+func (*Field) isSelection()          {}
+func (*FragmentSpread) isSelection() {}
+func (*InlineFragment) isSelection() {}
@@ -0,0 +1,203 @@
+package ast
+
+import "github.com/Sntree2mi8/gogqllexer"
+
+// https://spec.graphql.org/October2021/#SchemaDefinition
+type SchemaDefinition struct {
+	Description    *StringValue
+	Directives     []*Directive
+	OperationTypes []*OperationTypeDefinition
+	Position       gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#SchemaExtension
+type SchemaExtension struct {
+	Directives     []*Directive
+	OperationTypes []*OperationTypeDefinition
+	Position       gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#RootOperationTypeDefinition
+type OperationTypeDefinition struct {
+	Operation OperationType
+	Type      *NamedType
+	Position  gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ScalarTypeDefinition
+type ScalarTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ScalarTypeExtension
+type ScalarTypeExtension struct {
+	Name       *Name
+	Directives []*Directive
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ObjectTypeDefinition
+type ObjectTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Interfaces  []*NamedType
+	Directives  []*Directive
+	Fields      []*FieldDefinition
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ObjectTypeExtension
+type ObjectTypeExtension struct {
+	Name       *Name
+	Interfaces []*NamedType
+	Directives []*Directive
+	Fields     []*FieldDefinition
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#FieldDefinition
+type FieldDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Type        Type
+	Directives  []*Directive
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InputValueDefinition
+type InputValueDefinition struct {
+	Description  *StringValue
+	Name         *Name
+	Type         Type
+	DefaultValue Value
+	Directives   []*Directive
+	Position     gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InterfaceTypeDefinition
+type InterfaceTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Interfaces  []*NamedType
+	Directives  []*Directive
+	Fields      []*FieldDefinition
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InterfaceTypeExtension
+type InterfaceTypeExtension struct {
+	Name       *Name
+	Interfaces []*NamedType
+	Directives []*Directive
+	Fields     []*FieldDefinition
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#UnionTypeDefinition
+type UnionTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Types       []*NamedType
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#UnionTypeExtension
+type UnionTypeExtension struct {
+	Name       *Name
+	Directives []*Directive
+	Types      []*NamedType
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#EnumTypeDefinition
+type EnumTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Values      []*EnumValueDefinition
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#EnumTypeExtension
+type EnumTypeExtension struct {
+	Name       *Name
+	Directives []*Directive
+	Values     []*EnumValueDefinition
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#EnumValueDefinition
+type EnumValueDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InputObjectTypeDefinition
+type InputObjectTypeDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Fields      []*InputValueDefinition
+	Position    gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#InputObjectTypeExtension
+type InputObjectTypeExtension struct {
+	Name       *Name
+	Directives []*Directive
+	Fields     []*InputValueDefinition
+	Position   gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#DirectiveDefinition
+type DirectiveDefinition struct {
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Repeatable  bool
+	Locations   []*Name
+	Position    gogqllexer.Position
+}
+
+func (d *SchemaDefinition) GetPosition() gogqllexer.Position          { return d.Position }
+func (d *SchemaExtension) GetPosition() gogqllexer.Position           { return d.Position }
+func (d *OperationTypeDefinition) GetPosition() gogqllexer.Position   { return d.Position }
+func (d *ScalarTypeDefinition) GetPosition() gogqllexer.Position      { return d.Position }
+func (d *ScalarTypeExtension) GetPosition() gogqllexer.Position       { return d.Position }
+func (d *ObjectTypeDefinition) GetPosition() gogqllexer.Position      { return d.Position }
+func (d *ObjectTypeExtension) GetPosition() gogqllexer.Position       { return d.Position }
+func (d *FieldDefinition) GetPosition() gogqllexer.Position           { return d.Position }
+func (d *InputValueDefinition) GetPosition() gogqllexer.Position      { return d.Position }
+func (d *InterfaceTypeDefinition) GetPosition() gogqllexer.Position   { return d.Position }
+func (d *InterfaceTypeExtension) GetPosition() gogqllexer.Position    { return d.Position }
+func (d *UnionTypeDefinition) GetPosition() gogqllexer.Position       { return d.Position }
+func (d *UnionTypeExtension) GetPosition() gogqllexer.Position        { return d.Position }
+func (d *EnumTypeDefinition) GetPosition() gogqllexer.Position        { return d.Position }
+func (d *EnumTypeExtension) GetPosition() gogqllexer.Position         { return d.Position }
+func (d *EnumValueDefinition) GetPosition() gogqllexer.Position       { return d.Position }
+func (d *InputObjectTypeDefinition) GetPosition() gogqllexer.Position { return d.Position }
+func (d *InputObjectTypeExtension) GetPosition() gogqllexer.Position  { return d.Position }
+func (d *DirectiveDefinition) GetPosition() gogqllexer.Position       { return d.Position }
+
+func (*SchemaDefinition) isDefinition()          {}
+func (*SchemaExtension) isDefinition()           {}
+func (*ScalarTypeDefinition) isDefinition()      {}
+func (*ScalarTypeExtension) isDefinition()       {}
+func (*ObjectTypeDefinition) isDefinition()      {}
+func (*ObjectTypeExtension) isDefinition()       {}
+func (*InterfaceTypeDefinition) isDefinition()   {}
+func (*InterfaceTypeExtension) isDefinition()    {}
+func (*UnionTypeDefinition) isDefinition()       {}
+func (*UnionTypeExtension) isDefinition()        {}
+func (*EnumTypeDefinition) isDefinition()        {}
+func (*EnumTypeExtension) isDefinition()         {}
+func (*InputObjectTypeDefinition) isDefinition() {}
+func (*InputObjectTypeExtension) isDefinition()  {}
+func (*DirectiveDefinition) isDefinition()       {}
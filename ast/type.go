@@ -0,0 +1,35 @@
+package ast
+
+import "github.com/Sntree2mi8/gogqllexer"
+
+// https://spec.graphql.org/October2021/#Type
+type Type interface {
+	Node
+	isType()
+}
+
+// https://spec.graphql.org/October2021/#NamedType
+type NamedType struct {
+	Name     *Name
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ListType
+type ListType struct {
+	Type     Type
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#NonNullType
+type NonNullType struct {
+	Type     Type
+	Position gogqllexer.Position
+}
+
+func (t *NamedType) GetPosition() gogqllexer.Position   { return t.Position }
+func (t *ListType) GetPosition() gogqllexer.Position    { return t.Position }
+func (t *NonNullType) GetPosition() gogqllexer.Position { return t.Position }
+
+func (*NamedType) isType()   {}
+func (*ListType) isType()    {}
+func (*NonNullType) isType() {}
@@ -0,0 +1,94 @@
+package ast
+
+import "github.com/Sntree2mi8/gogqllexer"
+
+// https://spec.graphql.org/October2021/#Value
+type Value interface {
+	Node
+	isValue()
+}
+
+// https://spec.graphql.org/October2021/#Variable
+type Variable struct {
+	Name     *Name
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#IntValue
+type IntValue struct {
+	Value    string
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#FloatValue
+type FloatValue struct {
+	Value    string
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#StringValue
+type StringValue struct {
+	// Value is the string with escape sequences and block string indentation processed.
+	Value string
+	// Raw is the source text of the string including its quotes.
+	Raw      string
+	Block    bool
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#BooleanValue
+type BooleanValue struct {
+	Value    bool
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#NullValue
+type NullValue struct {
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#EnumValue
+type EnumValue struct {
+	Value    string
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ListValue
+type ListValue struct {
+	Values   []Value
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ObjectValue
+type ObjectValue struct {
+	Fields   []*ObjectField
+	Position gogqllexer.Position
+}
+
+// https://spec.graphql.org/October2021/#ObjectField
+type ObjectField struct {
+	Name     *Name
+	Value    Value
+	Position gogqllexer.Position
+}
+
+func (v *Variable) GetPosition() gogqllexer.Position     { return v.Position }
+func (v *IntValue) GetPosition() gogqllexer.Position     { return v.Position }
+func (v *FloatValue) GetPosition() gogqllexer.Position   { return v.Position }
+func (v *StringValue) GetPosition() gogqllexer.Position  { return v.Position }
+func (v *BooleanValue) GetPosition() gogqllexer.Position { return v.Position }
+func (v *NullValue) GetPosition() gogqllexer.Position    { return v.Position }
+func (v *EnumValue) GetPosition() gogqllexer.Position    { return v.Position }
+func (v *ListValue) GetPosition() gogqllexer.Position    { return v.Position }
+func (v *ObjectValue) GetPosition() gogqllexer.Position  { return v.Position }
+func (f *ObjectField) GetPosition() gogqllexer.Position  { return f.Position }
+
+func (*Variable) isValue()     {}
+func (*IntValue) isValue()     {}
+func (*FloatValue) isValue()   {}
+func (*StringValue) isValue()  {}
+func (*BooleanValue) isValue() {}
+func (*NullValue) isValue()    {}
+func (*EnumValue) isValue()    {}
+func (*ListValue) isValue()    {}
+func (*ObjectValue) isValue()  {}
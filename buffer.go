@@ -0,0 +1,145 @@
+package gogqllexer
+
+import "errors"
+
+type scannedToken struct {
+	token Token
+	err   *LexError
+}
+
+// tokenBuffer holds tokens scanned ahead by PeekToken and tokens kept for UnreadToken and Reset.
+type tokenBuffer struct {
+	tokens []scannedToken
+	// next is the index in tokens of the token NextToken returns next.
+	next int
+	// base is the number of tokens dropped from the front of tokens.
+	base int
+	// marks holds the ids of the marks that have been neither reset nor released.
+	// No token is dropped while there is one.
+	marks  []int
+	markID int
+}
+
+// Mark is a checkpoint in the token stream created by Lexer.Mark.
+type Mark struct {
+	id    int
+	index int
+}
+
+var ErrNothingToUnread = errors.New("gogqllexer: no token to unread")
+
+// ErrInvalidMark is returned by Reset and Release for a mark that has already been reset or released.
+var ErrInvalidMark = errors.New("gogqllexer: mark has already been reset or released")
+
+func (l *Lexer) NextToken() Token {
+	b := &l.buffer
+	if b.next == len(b.tokens) && len(b.marks) == 0 {
+		// nothing has been peeked and no mark needs the history,
+		// so the token is scanned into the single slot kept for UnreadToken
+		b.base += len(b.tokens)
+		if b.tokens == nil {
+			b.tokens = make([]scannedToken, 1)
+		}
+		b.tokens = b.tokens[:1]
+		b.next = 1
+		l.scan(&b.tokens[0])
+		return b.tokens[0].token
+	}
+
+	if b.next == len(b.tokens) {
+		b.tokens = append(b.tokens, scannedToken{})
+		l.scan(&b.tokens[len(b.tokens)-1])
+	}
+	st := b.tokens[b.next]
+	b.next++
+	l.err = st.err
+
+	// keep only the token just returned, for UnreadToken, while no mark needs the history
+	if len(b.marks) == 0 && b.next > 1 {
+		n := copy(b.tokens, b.tokens[b.next-1:])
+		b.base += b.next - 1
+		b.tokens = b.tokens[:n]
+		b.next = 1
+	}
+
+	return st.token
+}
+
+// PeekToken returns the token n positions ahead without consuming it.
+// PeekToken(0) is the token the next call to NextToken returns.
+func (l *Lexer) PeekToken(n int) Token {
+	b := &l.buffer
+	for len(b.tokens) <= b.next+n {
+		if last := len(b.tokens) - 1; last >= 0 && b.tokens[last].token.Kind == EOF {
+			return b.tokens[last].token
+		}
+		err := l.err
+		b.tokens = append(b.tokens, scannedToken{})
+		l.scan(&b.tokens[len(b.tokens)-1])
+		l.err = err
+	}
+
+	return b.tokens[b.next+n].token
+}
+
+// UnreadToken pushes back the token most recently returned by NextToken.
+func (l *Lexer) UnreadToken() error {
+	b := &l.buffer
+	if b.next == 0 {
+		return ErrNothingToUnread
+	}
+	b.next--
+	l.restoreErr()
+
+	return nil
+}
+
+// Mark returns a checkpoint to which Reset rewinds the token stream.
+// Tokens returned after Mark are kept until the mark is passed to Reset or Release.
+// Marks may be reset and released in any order, each of them once, and only on the lexer that created them.
+func (l *Lexer) Mark() Mark {
+	b := &l.buffer
+	b.markID++
+	b.marks = append(b.marks, b.markID)
+
+	return Mark{
+		id:    b.markID,
+		index: b.base + b.next,
+	}
+}
+
+// Reset rewinds the token stream to m and releases m.
+// It returns ErrInvalidMark without rewinding when m is no longer valid, see Release.
+func (l *Lexer) Reset(m Mark) error {
+	if err := l.Release(m); err != nil {
+		return err
+	}
+	// the tokens after m have been kept while m was valid
+	l.buffer.next = m.index - l.buffer.base
+	l.restoreErr()
+
+	return nil
+}
+
+// Release discards m without rewinding.
+// It returns ErrInvalidMark when m has already been reset or released.
+func (l *Lexer) Release(m Mark) error {
+	b := &l.buffer
+	for i, id := range b.marks {
+		if id == m.id {
+			b.marks = append(b.marks[:i], b.marks[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrInvalidMark
+}
+
+// restoreErr makes Err describe the token before the current buffer position.
+func (l *Lexer) restoreErr() {
+	b := &l.buffer
+	l.err = nil
+	if b.next > 0 {
+		l.err = b.tokens[b.next-1].err
+	}
+}
@@ -0,0 +1,112 @@
+package gogqllexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_PeekToken(t *testing.T) {
+	l := NewFromString("extend type T")
+
+	assert.Equal(t, "extend", l.PeekToken(0).Value)
+	assert.Equal(t, "type", l.PeekToken(1).Value)
+	assert.Equal(t, "T", l.PeekToken(2).Value)
+	assert.Equal(t, EOF, l.PeekToken(3).Kind)
+	assert.Equal(t, EOF, l.PeekToken(10).Kind)
+
+	assert.Equal(t, "extend", l.NextToken().Value)
+	assert.Equal(t, "T", l.PeekToken(1).Value)
+	assert.Equal(t, "type", l.NextToken().Value)
+	assert.Equal(t, "T", l.NextToken().Value)
+	assert.Equal(t, EOF, l.NextToken().Kind)
+}
+
+func TestLexer_PeekToken_Err(t *testing.T) {
+	l := New(strings.NewReader("a 01"))
+
+	assert.Equal(t, Invalid, l.PeekToken(1).Kind)
+	assert.NoError(t, l.Err())
+
+	l.NextToken()
+	assert.NoError(t, l.Err())
+	l.NextToken()
+	assert.Error(t, l.Err())
+}
+
+func TestLexer_UnreadToken(t *testing.T) {
+	l := NewFromString("a 01 b", WithErrorRecovery())
+
+	assert.ErrorIs(t, l.UnreadToken(), ErrNothingToUnread)
+
+	assert.Equal(t, "a", l.NextToken().Value)
+	assert.Equal(t, Invalid, l.NextToken().Kind)
+	assert.Error(t, l.Err())
+
+	assert.NoError(t, l.UnreadToken())
+	assert.NoError(t, l.Err())
+	assert.Equal(t, Invalid, l.NextToken().Kind)
+	assert.Error(t, l.Err())
+	assert.Equal(t, "b", l.NextToken().Value)
+}
+
+func TestLexer_Mark(t *testing.T) {
+	l := NewFromString("a b c d")
+
+	assert.Equal(t, "a", l.NextToken().Value)
+	outer := l.Mark()
+	assert.Equal(t, "b", l.NextToken().Value)
+	inner := l.Mark()
+	assert.Equal(t, "c", l.NextToken().Value)
+	assert.Equal(t, "d", l.NextToken().Value)
+
+	assert.NoError(t, l.Reset(inner))
+	assert.Equal(t, "c", l.NextToken().Value)
+
+	assert.NoError(t, l.Reset(outer))
+	assert.Equal(t, "b", l.NextToken().Value)
+	assert.Equal(t, "c", l.NextToken().Value)
+
+	m := l.Mark()
+	assert.Equal(t, "d", l.NextToken().Value)
+	assert.NoError(t, l.Release(m))
+	assert.Equal(t, EOF, l.NextToken().Kind)
+	assert.Len(t, l.buffer.tokens, 1)
+}
+
+func TestLexer_Mark_OutOfOrder(t *testing.T) {
+	l := NewFromString("a b c d")
+
+	outer := l.Mark()
+	assert.Equal(t, "a", l.NextToken().Value)
+	inner := l.Mark()
+	assert.Equal(t, "b", l.NextToken().Value)
+
+	// the inner mark stays valid when the outer one is released first
+	assert.NoError(t, l.Release(outer))
+	assert.Equal(t, "c", l.NextToken().Value)
+	assert.NoError(t, l.Reset(inner))
+	assert.Equal(t, "b", l.NextToken().Value)
+
+	// every mark is released, so the tokens before the current one are dropped
+	assert.Equal(t, "c", l.NextToken().Value)
+	assert.Len(t, l.buffer.tokens, 1)
+	assert.Equal(t, "d", l.NextToken().Value)
+}
+
+func TestLexer_Mark_Stale(t *testing.T) {
+	l := NewFromString("a b c")
+
+	m := l.Mark()
+	assert.Equal(t, "a", l.NextToken().Value)
+	assert.NoError(t, l.Reset(m))
+	assert.Equal(t, "a", l.NextToken().Value)
+	assert.Equal(t, "b", l.NextToken().Value)
+
+	// m has been reset and its tokens dropped, so it must not rewind again
+	assert.ErrorIs(t, l.Reset(m), ErrInvalidMark)
+	assert.ErrorIs(t, l.Release(m), ErrInvalidMark)
+	assert.ErrorIs(t, l.Reset(Mark{}), ErrInvalidMark)
+	assert.Equal(t, "c", l.NextToken().Value)
+}
@@ -0,0 +1,258 @@
+// Command gqllex prints the token stream of GraphQL documents.
+//
+//	gqllex [-format table|jsonl|inline] [-diagnostics short|text|json|sarif] [-comments] [file ...]
+//
+// It reads standard input when no file is given, and exits with status 1
+// when any Invalid token is encountered. The lexical errors are written to standard error
+// in the format selected by -diagnostics.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/diagnostic"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gqllex", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "table", "output format: table, jsonl or inline")
+	diagnostics := flags.String("diagnostics", "short", "format of the errors written to standard error: short, text, json or sarif")
+	comments := flags.Bool("comments", false, "print comments as tokens")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var printer func(w io.Writer, file, src string, tokens []token) error
+	switch *format {
+	case "table":
+		printer = printTable
+	case "jsonl":
+		printer = printJSONLines
+	case "inline":
+		printer = printInline
+	default:
+		fmt.Fprintf(stderr, "gqllex: unknown format %q\n", *format)
+		return 2
+	}
+
+	var writeDiagnostics func(w io.Writer, diagnostics []diagnostic.Diagnostic) error
+	switch *diagnostics {
+	case "short":
+	case "text":
+		writeDiagnostics = diagnostic.WriteText
+	case "json":
+		writeDiagnostics = diagnostic.WriteJSON
+	case "sarif":
+		writeDiagnostics = diagnostic.WriteSARIF
+	default:
+		fmt.Fprintf(stderr, "gqllex: unknown diagnostics format %q\n", *diagnostics)
+		return 2
+	}
+
+	opts := []gogqllexer.Option{gogqllexer.WithErrorRecovery()}
+	if *comments {
+		opts = append(opts, gogqllexer.WithComments())
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+	var reported []diagnostic.Diagnostic
+	for _, file := range files {
+		name, src, err := readInput(file, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gqllex: %v\n", err)
+			return 2
+		}
+
+		tokens := lex(src, opts)
+		if err := printer(stdout, name, src, tokens); err != nil {
+			fmt.Fprintf(stderr, "gqllex: %v\n", err)
+			return 2
+		}
+		// the diagnostics are made from the errors of the printed tokens, so that both agree
+		source := gogqllexer.NewSource(name, src)
+		for _, t := range tokens {
+			if t.err == nil {
+				continue
+			}
+			if writeDiagnostics == nil {
+				fmt.Fprintf(stderr, "%s:%d:%d: %s\n", name, t.Span.Start.Line, t.Span.Start.Column, t.err.Message)
+			} else {
+				reported = append(reported, diagnostic.New(source, t.err))
+			}
+			status = 1
+		}
+	}
+	if writeDiagnostics != nil {
+		if err := writeDiagnostics(stderr, reported); err != nil {
+			fmt.Fprintf(stderr, "gqllex: %v\n", err)
+			return 2
+		}
+	}
+
+	return status
+}
+
+func readInput(file string, stdin io.Reader) (name string, src string, err error) {
+	if file == "-" {
+		b, err := io.ReadAll(stdin)
+		return "<stdin>", string(b), err
+	}
+
+	b, err := os.ReadFile(file)
+	return file, string(b), err
+}
+
+type token struct {
+	gogqllexer.Token
+	err *gogqllexer.LexError
+}
+
+func lex(src string, opts []gogqllexer.Option) []token {
+	var tokens []token
+
+	l := gogqllexer.NewFromString(src, opts...)
+	for {
+		t := token{
+			Token: l.NextToken(),
+		}
+		t.err, _ = l.Err().(*gogqllexer.LexError)
+		tokens = append(tokens, t)
+		if t.Kind == gogqllexer.EOF {
+			return tokens
+		}
+	}
+}
+
+func printTable(w io.Writer, file, src string, tokens []token) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "POSITION\tKIND\tVALUE")
+	for _, t := range tokens {
+		var value string
+		switch {
+		case t.Kind.IsPunctuator():
+			value = t.Kind.Text()
+		case t.Kind == gogqllexer.Invalid:
+			value = strconv.Quote(src[t.Span.Start.Offset:t.Span.End.Offset])
+		case t.Value != "":
+			value = strconv.Quote(t.Value)
+		}
+		fmt.Fprintf(tw, "%s:%d:%d\t%s\t%s\n", file, t.Span.Start.Line, t.Span.Start.Column, t.Kind, value)
+	}
+
+	return tw.Flush()
+}
+
+type jsonToken struct {
+	File      string          `json:"file"`
+	Kind      gogqllexer.Kind `json:"kind"`
+	Value     string          `json:"value,omitempty"`
+	Line      int             `json:"line"`
+	Column    int             `json:"column"`
+	Offset    int             `json:"offset"`
+	EndLine   int             `json:"endLine"`
+	EndColumn int             `json:"endColumn"`
+	EndOffset int             `json:"endOffset"`
+	Error     string          `json:"error,omitempty"`
+}
+
+func printJSONLines(w io.Writer, file, src string, tokens []token) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, t := range tokens {
+		jt := jsonToken{
+			File:      file,
+			Kind:      t.Kind,
+			Value:     t.Value,
+			Line:      t.Span.Start.Line,
+			Column:    t.Span.Start.Column,
+			Offset:    t.Span.Start.Offset,
+			EndLine:   t.Span.End.Line,
+			EndColumn: t.Span.End.Column,
+			EndOffset: t.Span.End.Offset,
+		}
+		if t.err != nil {
+			jt.Value = src[t.Span.Start.Offset:t.Span.End.Offset]
+			jt.Error = t.err.Message
+		}
+		if err := enc.Encode(jt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printInline prints every source line followed by a marker line
+// underlining each token with '^', or with '~' for invalid tokens.
+func printInline(w io.Writer, file, src string, tokens []token) error {
+	marks := make([]byte, len(src))
+	for _, t := range tokens {
+		mark := byte('^')
+		if t.Kind == gogqllexer.Invalid {
+			mark = '~'
+		}
+		for i := t.Span.Start.Offset; i < t.Span.End.Offset; i++ {
+			marks[i] = mark
+		}
+	}
+
+	fmt.Fprintf(w, "%s:\n", file)
+	line := 1
+	for start := 0; start < len(src) || start == 0; {
+		end := strings.IndexAny(src[start:], "\r\n")
+		next := len(src)
+		if end < 0 {
+			end = len(src)
+		} else {
+			end += start
+			next = end + 1
+			if src[end] == '\r' && next < len(src) && src[next] == '\n' {
+				next++
+			}
+		}
+
+		var marker strings.Builder
+		for i := start; i < end; {
+			r, size := utf8.DecodeRuneInString(src[i:end])
+			switch {
+			case marks[i] != 0:
+				marker.WriteByte(marks[i])
+			case r == '\t':
+				marker.WriteByte('\t')
+			default:
+				marker.WriteByte(' ')
+			}
+			i += size
+		}
+
+		if _, err := fmt.Fprintf(w, "%4d | %s\n     | %s\n", line, src[start:end], strings.TrimRight(marker.String(), " \t")); err != nil {
+			return err
+		}
+		line++
+		if next >= len(src) {
+			break
+		}
+		start = next
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStatus int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "table",
+			args:       []string{},
+			stdin:      "{ a(x: 1) }",
+			wantStatus: 0,
+			wantStdout: `POSITION      KIND    VALUE
+<stdin>:1:1   BraceL  {
+<stdin>:1:3   Name    "a"
+<stdin>:1:4   ParenL  (
+<stdin>:1:5   Name    "x"
+<stdin>:1:6   Colon   :
+<stdin>:1:8   Int     "1"
+<stdin>:1:9   ParenR  )
+<stdin>:1:11  BraceR  }
+<stdin>:1:12  EOF     
+`,
+		},
+		{
+			name:       "json lines",
+			args:       []string{"-format", "jsonl", "-comments"},
+			stdin:      "a #c\n0x",
+			wantStatus: 1,
+			wantStdout: `{"file":"<stdin>","kind":"Name","value":"a","line":1,"column":1,"offset":0,"endLine":1,"endColumn":2,"endOffset":1}
+{"file":"<stdin>","kind":"Comment","value":"#c","line":1,"column":3,"offset":2,"endLine":1,"endColumn":5,"endOffset":4}
+{"file":"<stdin>","kind":"Invalid","value":"0x","line":2,"column":1,"offset":5,"endLine":2,"endColumn":3,"endOffset":7,"error":"unexpected character 'x' after number"}
+{"file":"<stdin>","kind":"EOF","line":2,"column":3,"offset":7,"endLine":2,"endColumn":3,"endOffset":7}
+`,
+			wantStderr: "<stdin>:2:1: unexpected character 'x' after number\n",
+		},
+		{
+			name:       "inline",
+			args:       []string{"-format", "inline"},
+			stdin:      "query {\r\n  \"abc\n}",
+			wantStatus: 1,
+			wantStdout: `<stdin>:
+   1 | query {
+     | ^^^^^ ^
+   2 |   "abc
+     |   ~~~~
+   3 | }
+     | ^
+`,
+			wantStderr: "<stdin>:2:3: unterminated string\n",
+		},
+		{
+			name:       "text diagnostics",
+			args:       []string{"-format", "inline", "-diagnostics", "text"},
+			stdin:      "01",
+			wantStatus: 1,
+			wantStdout: `<stdin>:
+   1 | 01
+     | ~~
+`,
+			wantStderr: `error[LeadingZero]: unexpected digit after 0
+ --> <stdin>:1:1
+1 | 01
+  | ^^
+  = hint: leading zeros are not allowed
+
+1 error
+`,
+		},
+		{
+			name:       "unknown diagnostics format",
+			args:       []string{"-diagnostics", "xml"},
+			wantStatus: 2,
+			wantStderr: "gqllex: unknown diagnostics format \"xml\"\n",
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"-format", "xml"},
+			wantStatus: 2,
+			wantStderr: "gqllex: unknown format \"xml\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			got := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantStatus, got)
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
+
+func TestRun_Files(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "query.graphql")
+	assert.NoError(t, os.WriteFile(file, []byte("{ a }"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	got := run([]string{"-format", "jsonl", file}, nil, &stdout, &stderr)
+
+	assert.Equal(t, 0, got)
+	assert.Equal(t, 4, strings.Count(stdout.String(), `"file":"`+file+`"`))
+
+	got = run([]string{filepath.Join(dir, "missing.graphql")}, nil, &stdout, &stderr)
+	assert.Equal(t, 2, got)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/parser"
+)
+
+type document struct {
+	uri  string
+	text string
+	// tokens excludes EOF and comments, and includes the Invalid tokens of errs.
+	tokens []gogqllexer.Token
+	errs   []*gogqllexer.LexError
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{
+		uri:  uri,
+		text: text,
+	}
+
+	l := gogqllexer.NewFromString(text, gogqllexer.WithErrorRecovery())
+	for {
+		t := l.NextToken()
+		if t.Kind == gogqllexer.EOF {
+			break
+		}
+		var err *gogqllexer.LexError
+		if t.Kind == gogqllexer.Invalid && errors.As(l.Err(), &err) {
+			d.errs = append(d.errs, err)
+		}
+		d.tokens = append(d.tokens, t)
+	}
+
+	return d
+}
+
+func toPosition(loc gogqllexer.Location) Position {
+	return Position{
+		Line:      loc.Line - 1,
+		Character: loc.UTF16Column - 1,
+	}
+}
+
+func toRange(span gogqllexer.Span) Range {
+	return Range{
+		Start: toPosition(span.Start),
+		End:   toPosition(span.End),
+	}
+}
+
+// isTypeSystem reports whether the document is a schema rather than an executable document,
+// judging by its first token.
+func (d *document) isTypeSystem() bool {
+	if len(d.tokens) == 0 {
+		return false
+	}
+	switch t := d.tokens[0]; t.Kind {
+	case gogqllexer.String, gogqllexer.BlockString:
+		return true
+	case gogqllexer.Name:
+		return gogqllexer.Keywords[t.Value] == gogqllexer.TypeSystemKeyword
+	default:
+		return false
+	}
+}
+
+// diagnostics reports every lexical error, or the first syntax error when there are none.
+func (d *document) diagnostics() []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, err := range d.errs {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    toRange(err.Span),
+			Severity: SeverityError,
+			Code:     err.Code.String(),
+			Source:   "gqlls",
+			Message:  err.Message,
+		})
+	}
+	if len(diagnostics) > 0 {
+		return diagnostics
+	}
+
+	parse := parser.ParseExecutable
+	if d.isTypeSystem() {
+		parse = parser.ParseSchema
+	}
+	_, err := parse(gogqllexer.NewFromString(d.text))
+
+	var parseErr *parser.Error
+	if errors.As(err, &parseErr) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    toRange(parseErr.Span),
+			Severity: SeverityError,
+			Source:   "gqlls",
+			Message:  parseErr.Message,
+		})
+	}
+
+	return diagnostics
+}
+
+// symbols returns the operations and fragments of the document.
+// It works on tokens rather than the AST so that symbols stay available while the document does not parse.
+func (d *document) symbols() []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+
+	var (
+		current *DocumentSymbol
+		depth   int
+	)
+	for i, t := range d.tokens {
+		switch t.Kind {
+		case gogqllexer.Name:
+			if depth > 0 || current != nil {
+				continue
+			}
+			switch {
+			case gogqllexer.Keywords[t.Value] == gogqllexer.OperationKeyword:
+				current = &DocumentSymbol{
+					Name:           "<anonymous>",
+					Detail:         t.Value,
+					Kind:           SymbolKindFunction,
+					SelectionRange: toRange(t.Span),
+				}
+			case gogqllexer.Keywords[t.Value] == gogqllexer.FragmentKeyword:
+				current = &DocumentSymbol{
+					Detail:         t.Value,
+					Kind:           SymbolKindClass,
+					SelectionRange: toRange(t.Span),
+				}
+			default:
+				continue
+			}
+			current.Range.Start = toPosition(t.Span.Start)
+			if i+1 < len(d.tokens) && d.tokens[i+1].Kind == gogqllexer.Name && d.tokens[i+1].Value != "on" {
+				current.Name = d.tokens[i+1].Value
+				current.SelectionRange = toRange(d.tokens[i+1].Span)
+			}
+		case gogqllexer.BraceL, gogqllexer.ParenL, gogqllexer.BracketL:
+			if t.Kind == gogqllexer.BraceL && depth == 0 && current == nil && (i == 0 || d.tokens[i-1].Kind == gogqllexer.BraceR) {
+				// shorthand query
+				current = &DocumentSymbol{
+					Name:           "<anonymous>",
+					Detail:         "query",
+					Kind:           SymbolKindFunction,
+					Range:          Range{Start: toPosition(t.Span.Start)},
+					SelectionRange: toRange(t.Span),
+				}
+			}
+			depth++
+		case gogqllexer.BraceR, gogqllexer.ParenR, gogqllexer.BracketR:
+			if depth > 0 {
+				depth--
+			}
+			if t.Kind == gogqllexer.BraceR && depth == 0 && current != nil {
+				current.Range.End = toPosition(t.Span.End)
+				if current.Name == "" {
+					current.Name = "<anonymous>"
+				}
+				symbols = append(symbols, *current)
+				current = nil
+			}
+		}
+	}
+
+	return symbols
+}
+
+// definition returns the location of the fragment definition named by the fragment spread at pos.
+func (d *document) definition(pos Position) []Location {
+	locations := []Location{}
+
+	for i, t := range d.tokens {
+		if t.Kind != gogqllexer.Name || i == 0 || d.tokens[i-1].Kind != gogqllexer.Spread || t.Value == "on" {
+			continue
+		}
+		r := toRange(t.Span)
+		if pos.Line != r.Start.Line || pos.Character < r.Start.Character || pos.Character > r.End.Character {
+			continue
+		}
+
+		for _, s := range d.symbols() {
+			if s.Kind == SymbolKindClass && s.Name == t.Value {
+				locations = append(locations, Location{
+					URI:   d.uri,
+					Range: s.SelectionRange,
+				})
+			}
+		}
+		break
+	}
+
+	return locations
+}
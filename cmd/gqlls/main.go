@@ -0,0 +1,27 @@
+// Command gqlls is a Language Server Protocol server for GraphQL documents.
+//
+//	gqlls
+//
+// It speaks LSP over standard input and output and provides diagnostics for
+// lexical and syntax errors, semantic tokens, document symbols for operations
+// and fragments, and go-to-definition for fragment spreads.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(stdin io.Reader, stdout, stderr io.Writer) int {
+	if err := serve(stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "gqlls: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
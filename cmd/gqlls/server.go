@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/Sntree2mi8/gogqllexer/highlight"
+)
+
+var errExitWithoutShutdown = errors.New("exit notification received before shutdown request")
+
+type server struct {
+	out io.Writer
+	// err is the first error writing a notification, which ends serve like an error writing a response.
+	err      error
+	docs     map[string]*document
+	shutdown bool
+}
+
+// serve reads requests from in and writes responses and notifications to out
+// until the exit notification or the end of in.
+func serve(in io.Reader, out io.Writer) error {
+	s := &server{
+		out:  out,
+		docs: map[string]*document{},
+	}
+
+	r := bufio.NewReader(in)
+	for {
+		content, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			err = s.reply(json.RawMessage("null"), nil, &responseError{Code: codeParseError, Message: err.Error()})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+			return nil
+		}
+
+		// a message without an id is a notification, an id that is present must be a number or a string
+		if req.ID != nil && !validID(req.ID) {
+			if err := s.reply(json.RawMessage("null"), nil, &responseError{Code: codeInvalidRequest, Message: "invalid id " + string(req.ID)}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, respErr := s.handle(req)
+		if s.err != nil {
+			return s.err
+		}
+		if req.ID == nil {
+			// notifications are never answered
+			continue
+		}
+		if err := s.reply(req.ID, result, respErr); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) reply(id json.RawMessage, result any, respErr *responseError) error {
+	resp := response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   respErr,
+	}
+	if respErr == nil {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		resp.Result = b
+	}
+
+	return writeMessage(s.out, resp)
+}
+
+func (s *server) notify(method string, params any) {
+	if s.err != nil {
+		return
+	}
+	s.err = writeMessage(s.out, notification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+func (s *server) handle(req request) (any, *responseError) {
+	if s.shutdown && req.ID != nil {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: TextDocumentSyncKindFull,
+				SemanticTokensProvider: SemanticTokensOptions{
+					Legend: SemanticTokensLegend{
+						TokenTypes:     highlight.SemanticTokenTypes,
+						TokenModifiers: []string{},
+					},
+					Full: true,
+				},
+				DocumentSymbolProvider: true,
+				DefinitionProvider:     true,
+			},
+			ServerInfo: ServerInfo{
+				Name: "gqlls",
+			},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// with full synchronization the last change holds the whole document
+		s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, params.TextDocument.URI)
+		s.publish(PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		return nil, nil
+	case "textDocument/semanticTokens/full":
+		d, respErr := s.document(req.Params)
+		if respErr != nil {
+			return nil, respErr
+		}
+		return SemanticTokens{Data: append([]uint32{}, highlight.SemanticTokens(d.text)...)}, nil
+	case "textDocument/documentSymbol":
+		d, respErr := s.document(req.Params)
+		if respErr != nil {
+			return nil, respErr
+		}
+		return d.symbols(), nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		d, respErr := s.document(req.Params)
+		if respErr != nil {
+			return nil, respErr
+		}
+		return d.definition(params.Position), nil
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *server) update(uri, text string) {
+	d := newDocument(uri, text)
+	s.docs[uri] = d
+
+	s.publish(PublishDiagnosticsParams{URI: uri, Diagnostics: d.diagnostics()})
+}
+
+func (s *server) publish(params PublishDiagnosticsParams) {
+	s.notify("textDocument/publishDiagnostics", params)
+}
+
+func (s *server) document(params json.RawMessage) (*document, *responseError) {
+	var p DocumentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+	d, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, &responseError{Code: codeInvalidParams, Message: "unknown document: " + p.TextDocument.URI}
+	}
+
+	return d, nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
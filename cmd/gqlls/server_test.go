@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sntree2mi8/gogqllexer/highlight"
+	"github.com/stretchr/testify/assert"
+)
+
+// testClient talks to a server running in-process over pipes.
+type testClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	msgs   chan json.RawMessage
+	done   chan error
+	nextID int
+	// notifications holds the notifications received while waiting for a response.
+	notifications []notification
+}
+
+func newTestClient(t *testing.T) *testClient {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &testClient{
+		t:    t,
+		in:   inW,
+		msgs: make(chan json.RawMessage, 16),
+		done: make(chan error, 1),
+	}
+
+	go func() {
+		c.done <- serve(inR, outW)
+		outW.Close()
+	}()
+	go func() {
+		defer close(c.msgs)
+		r := bufio.NewReader(outR)
+		for {
+			content, err := readMessage(r)
+			if err != nil {
+				return
+			}
+			c.msgs <- content
+		}
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+	})
+
+	return c
+}
+
+func (c *testClient) send(v any) {
+	c.t.Helper()
+	assert.NoError(c.t, writeMessage(c.in, v))
+}
+
+func (c *testClient) receive() json.RawMessage {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.msgs:
+		if !ok {
+			c.t.Fatal("server closed the connection")
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for a message")
+		return nil
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	c.send(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (c *testClient) call(method string, params, result any) *responseError {
+	c.t.Helper()
+	c.nextID++
+	id, _ := json.Marshal(c.nextID)
+	raw, _ := json.Marshal(params)
+	c.send(request{JSONRPC: "2.0", ID: id, Method: method, Params: raw})
+
+	for {
+		msg := c.receive()
+		var resp response
+		assert.NoError(c.t, json.Unmarshal(msg, &resp))
+		if resp.ID == nil {
+			var n notification
+			assert.NoError(c.t, json.Unmarshal(msg, &n))
+			c.notifications = append(c.notifications, n)
+			continue
+		}
+
+		assert.JSONEq(c.t, string(id), string(resp.ID))
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil {
+			assert.NoError(c.t, json.Unmarshal(resp.Result, result))
+		}
+		return nil
+	}
+}
+
+func (c *testClient) diagnostics() PublishDiagnosticsParams {
+	c.t.Helper()
+
+	var msg json.RawMessage
+	if len(c.notifications) > 0 {
+		msg, _ = json.Marshal(c.notifications[0])
+		c.notifications = c.notifications[1:]
+	} else {
+		msg = c.receive()
+	}
+
+	var n struct {
+		Method string
+		Params PublishDiagnosticsParams
+	}
+	assert.NoError(c.t, json.Unmarshal(msg, &n))
+	assert.Equal(c.t, "textDocument/publishDiagnostics", n.Method)
+	return n.Params
+}
+
+func (c *testClient) open(uri, text string) PublishDiagnosticsParams {
+	c.t.Helper()
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "graphql", Version: 1, Text: text},
+	})
+	return c.diagnostics()
+}
+
+func (c *testClient) wait() error {
+	c.t.Helper()
+	select {
+	case err := <-c.done:
+		return err
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for the server to exit")
+		return nil
+	}
+}
+
+func rng(startLine, startChar, endLine, endChar int) Range {
+	return Range{
+		Start: Position{Line: startLine, Character: startChar},
+		End:   Position{Line: endLine, Character: endChar},
+	}
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	c := newTestClient(t)
+
+	var result InitializeResult
+	assert.Nil(t, c.call("initialize", map[string]any{"capabilities": map[string]any{}}, &result))
+	assert.Equal(t, "gqlls", result.ServerInfo.Name)
+	assert.Equal(t, TextDocumentSyncKindFull, result.Capabilities.TextDocumentSync)
+	assert.Equal(t, highlight.SemanticTokenTypes, result.Capabilities.SemanticTokensProvider.Legend.TokenTypes)
+	assert.True(t, result.Capabilities.DocumentSymbolProvider)
+	assert.True(t, result.Capabilities.DefinitionProvider)
+	c.notify("initialized", struct{}{})
+
+	err := c.call("textDocument/hover", nil, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, codeMethodNotFound, err.Code)
+	}
+
+	assert.Nil(t, c.call("shutdown", nil, nil))
+	c.notify("exit", nil)
+	assert.NoError(t, c.wait())
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	c := newTestClient(t)
+	c.notify("exit", nil)
+	assert.Equal(t, errExitWithoutShutdown, c.wait())
+}
+
+func TestServer_NullID(t *testing.T) {
+	var in, out bytes.Buffer
+	assert.NoError(t, writeMessage(&in, json.RawMessage(`{"jsonrpc": "2.0", "id": null, "method": "shutdown"}`)))
+	assert.NoError(t, writeMessage(&in, notification{JSONRPC: "2.0", Method: "exit"}))
+
+	// the shutdown request is rejected rather than handled, so exit comes before shutdown
+	assert.Equal(t, errExitWithoutShutdown, serve(&in, &out))
+
+	content, err := readMessage(bufio.NewReader(&out))
+	assert.NoError(t, err)
+	var resp response
+	assert.NoError(t, json.Unmarshal(content, &resp))
+	assert.Equal(t, "null", string(resp.ID))
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, codeInvalidRequest, resp.Error.Code)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestServer_NotificationWriteError(t *testing.T) {
+	var in bytes.Buffer
+	assert.NoError(t, writeMessage(&in, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/didOpen",
+		Params: DidOpenTextDocumentParams{
+			TextDocument: TextDocumentItem{URI: "file:///q.graphql", LanguageID: "graphql", Version: 1, Text: "{ a }"},
+		},
+	}))
+
+	assert.EqualError(t, serve(&in, failingWriter{}), "broken pipe")
+}
+
+func TestReadMessage_ContentLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantErr string
+	}{
+		{
+			name:    "too large",
+			header:  "Content-Length: 1000000000000\r\n\r\n",
+			wantErr: "message of 1000000000000 bytes exceeds the limit of 67108864 bytes",
+		},
+		{
+			name:    "negative",
+			header:  "Content-Length: -1\r\n\r\n",
+			wantErr: `invalid Content-Length header " -1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readMessage(bufio.NewReader(strings.NewReader(tt.header)))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Diagnostic
+	}{
+		{
+			name: "valid",
+			src:  "query Q { a }",
+			want: []Diagnostic{},
+		},
+		{
+			name: "lexical errors",
+			src:  "{\n  a(x: 01)\n  b(s: \"\U0001F600\\q\")\n}",
+			want: []Diagnostic{
+				{Range: rng(1, 7, 1, 9), Severity: SeverityError, Code: "LeadingZero", Source: "gqlls", Message: "unexpected digit after 0"},
+				{Range: rng(2, 7, 2, 13), Severity: SeverityError, Code: "InvalidEscapeSequence", Source: "gqlls", Message: `invalid escape sequence "\q"`},
+			},
+		},
+		{
+			name: "syntax error at end of input",
+			src:  "{ a",
+			want: []Diagnostic{
+				{Range: rng(0, 3, 0, 3), Severity: SeverityError, Source: "gqlls", Message: "expected Name, found <EOF>"},
+			},
+		},
+		{
+			name: "syntax error in schema",
+			src:  "type T {\n  f Int\n}",
+			want: []Diagnostic{
+				{Range: rng(1, 4, 1, 7), Severity: SeverityError, Source: "gqlls", Message: `expected ":", found Name "Int"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			got := c.open("file:///a.graphql", tt.src)
+			assert.Equal(t, "file:///a.graphql", got.URI)
+			assert.Equal(t, tt.want, got.Diagnostics)
+		})
+	}
+}
+
+func TestServer_DidChange(t *testing.T) {
+	c := newTestClient(t)
+	uri := "file:///a.graphql"
+
+	assert.Len(t, c.open(uri, "{ a").Diagnostics, 1)
+
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   TextDocumentIdentifier{URI: uri},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: "{ a }"}},
+	})
+	assert.Empty(t, c.diagnostics().Diagnostics)
+
+	c.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+	assert.Empty(t, c.diagnostics().Diagnostics)
+
+	err := c.call("textDocument/documentSymbol", DocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}}, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, codeInvalidParams, err.Code)
+	}
+}
+
+func TestServer_Features(t *testing.T) {
+	c := newTestClient(t)
+	uri := "file:///q.graphql"
+	src := "query Q($v: Int = {a: 1}) {\n  ...F\n}\n\n{ b }\n\nfragment F on T {\n  a\n}\n"
+	c.open(uri, src)
+	doc := TextDocumentIdentifier{URI: uri}
+
+	var symbols []DocumentSymbol
+	assert.Nil(t, c.call("textDocument/documentSymbol", DocumentParams{TextDocument: doc}, &symbols))
+	assert.Equal(t, []DocumentSymbol{
+		{Name: "Q", Detail: "query", Kind: SymbolKindFunction, Range: rng(0, 0, 2, 1), SelectionRange: rng(0, 6, 0, 7)},
+		{Name: "<anonymous>", Detail: "query", Kind: SymbolKindFunction, Range: rng(4, 0, 4, 5), SelectionRange: rng(4, 0, 4, 1)},
+		{Name: "F", Detail: "fragment", Kind: SymbolKindClass, Range: rng(6, 0, 8, 1), SelectionRange: rng(6, 9, 6, 10)},
+	}, symbols)
+
+	var locations []Location
+	assert.Nil(t, c.call("textDocument/definition", TextDocumentPositionParams{TextDocument: doc, Position: Position{Line: 1, Character: 5}}, &locations))
+	assert.Equal(t, []Location{{URI: uri, Range: rng(6, 9, 6, 10)}}, locations)
+
+	assert.Nil(t, c.call("textDocument/definition", TextDocumentPositionParams{TextDocument: doc, Position: Position{Line: 7, Character: 2}}, &locations))
+	assert.Empty(t, locations)
+
+	var tokens SemanticTokens
+	assert.Nil(t, c.call("textDocument/semanticTokens/full", DocumentParams{TextDocument: doc}, &tokens))
+	assert.Equal(t, highlight.SemanticTokens(src), tokens.Data)
+}
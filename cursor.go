@@ -0,0 +1,43 @@
+package gogqllexer
+
+type cursor struct {
+	Location
+
+	// last is the most recently read rune, used to treat "\r\n" as a single line terminator.
+	last rune
+}
+
+func newCursor() cursor {
+	return cursor{
+		Location: Location{
+			Offset:      0,
+			Line:        1,
+			Column:      1,
+			UTF16Column: 1,
+		},
+	}
+}
+
+func (c *cursor) advance(r rune, size int) {
+	c.Offset += size
+
+	switch {
+	case r == '\n' && c.last == '\r':
+		// the line has already been advanced by '\r'
+	case isLineTerminator(r):
+		c.Line++
+		c.Column = 1
+		c.UTF16Column = 1
+	default:
+		c.Column += size
+		c.UTF16Column += utf16Len(r)
+	}
+	c.last = r
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,145 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Sntree2mi8/gogqllexer"
+)
+
+// Diagnostic is a lexical error in a source, with a hint on how to fix it when one is known.
+type Diagnostic struct {
+	Source  *gogqllexer.Source
+	Code    gogqllexer.ErrorCode
+	Message string
+	Hint    string
+	Span    gogqllexer.Span
+}
+
+// Lex lexes source with error recovery and returns a diagnostic for every lexical error.
+func Lex(source *gogqllexer.Source, opts ...gogqllexer.Option) []Diagnostic {
+	opts = append(opts[:len(opts):len(opts)], gogqllexer.WithErrorRecovery())
+	l := source.Lexer(opts...)
+
+	var diagnostics []Diagnostic
+	for t := l.NextToken(); t.Kind != gogqllexer.EOF; t = l.NextToken() {
+		var err *gogqllexer.LexError
+		if t.Kind != gogqllexer.Invalid || !errors.As(l.Err(), &err) {
+			continue
+		}
+		diagnostics = append(diagnostics, New(source, err))
+	}
+
+	return diagnostics
+}
+
+// New returns the diagnostic of err, a lexical error returned by a lexer of source.
+// It lets a caller that already lexed source report its errors without lexing it again.
+func New(source *gogqllexer.Source, err *gogqllexer.LexError) Diagnostic {
+	return Diagnostic{
+		Source:  source,
+		Code:    err.Code,
+		Message: err.Message,
+		Hint:    hint(err),
+		Span:    err.Span,
+	}
+}
+
+func hint(err *gogqllexer.LexError) string {
+	switch err.Code {
+	case gogqllexer.ErrIncompleteSpread:
+		return "did you mean `...`?"
+	case gogqllexer.ErrLeadingZero:
+		return "leading zeros are not allowed"
+	case gogqllexer.ErrUnexpectedCharacter:
+		if err.Text == "'" {
+			return "did you mean `\"`?"
+		}
+	case gogqllexer.ErrUnterminatedString:
+		if !strings.HasPrefix(err.Text, `"""`) && strings.ContainsAny(err.Text, "\r\n") {
+			return "strings cannot span lines, did you mean `\"\"\"`?"
+		}
+	case gogqllexer.ErrInvalidEscapeSequence:
+		if err.Spec == gogqllexer.Draft {
+			return `the escape sequences are \" \\ \/ \b \f \n \r \t, \uXXXX and \u{X...}`
+		}
+		return `the escape sequences are \" \\ \/ \b \f \n \r \t and \uXXXX`
+	}
+
+	return ""
+}
+
+// WriteText writes the diagnostics with the source lines they point at:
+//
+//	error[LeadingZero]: unexpected digit after 0
+//	 --> file.graphql:1:8
+//	1 | { a(x: 01) }
+//	  |        ^^
+//	  = hint: leading zeros are not allowed
+func WriteText(w io.Writer, diagnostics []Diagnostic) error {
+	var b strings.Builder
+	for _, d := range diagnostics {
+		location, snippet, _ := strings.Cut(d.Source.Snippet(d.Span), "\n")
+		gutter := strings.Repeat(" ", len(strconv.Itoa(d.Source.Location(d.Span.End.Offset).Line)))
+
+		fmt.Fprintf(&b, "error[%s]: %s\n", d.Code, d.Message)
+		fmt.Fprintf(&b, "%s--> %s\n", gutter, location)
+		b.WriteString(snippet)
+		if d.Hint != "" {
+			fmt.Fprintf(&b, "%s = hint: %s\n", gutter, d.Hint)
+		}
+		b.WriteString("\n")
+	}
+	switch len(diagnostics) {
+	case 0:
+	case 1:
+		b.WriteString("1 error\n")
+	default:
+		fmt.Fprintf(&b, "%d errors\n", len(diagnostics))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonDiagnostic struct {
+	File      string `json:"file"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Hint      string `json:"hint,omitempty"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Offset    int    `json:"offset"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	EndOffset int    `json:"endOffset"`
+}
+
+// WriteJSON writes the diagnostics as a JSON array.
+// Lines and columns are 1-based, and columns and offsets count bytes.
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+	out := make([]jsonDiagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		out = append(out, jsonDiagnostic{
+			File:      d.Source.Name,
+			Code:      d.Code.String(),
+			Message:   d.Message,
+			Hint:      d.Hint,
+			Line:      d.Span.Start.Line,
+			Column:    d.Span.Start.Column,
+			Offset:    d.Span.Start.Offset,
+			EndLine:   d.Span.End.Line,
+			EndColumn: d.Span.End.Column,
+			EndOffset: d.Span.End.Offset,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,225 @@
+package diagnostic
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		opts     []gogqllexer.Option
+		wantCode gogqllexer.ErrorCode
+		wantHint string
+	}{
+		{
+			name:     "lone dot",
+			src:      "{ a { .F } }",
+			wantCode: gogqllexer.ErrIncompleteSpread,
+			wantHint: "did you mean `...`?",
+		},
+		{
+			name:     "leading zero",
+			src:      "{ a(x: 007) }",
+			wantCode: gogqllexer.ErrLeadingZero,
+			wantHint: "leading zeros are not allowed",
+		},
+		{
+			name:     "single quote",
+			src:      "'",
+			wantCode: gogqllexer.ErrUnexpectedCharacter,
+			wantHint: "did you mean `\"`?",
+		},
+		{
+			name:     "string spanning lines",
+			src:      "\"a\nb",
+			wantCode: gogqllexer.ErrUnterminatedString,
+			wantHint: "strings cannot span lines, did you mean `\"\"\"`?",
+		},
+		{
+			name:     "unterminated string at end of input",
+			src:      "\"a",
+			wantCode: gogqllexer.ErrUnterminatedString,
+		},
+		{
+			name:     "invalid escape sequence",
+			src:      `"\q"`,
+			wantCode: gogqllexer.ErrInvalidEscapeSequence,
+			wantHint: `the escape sequences are \" \\ \/ \b \f \n \r \t and \uXXXX`,
+		},
+		{
+			name:     "invalid escape sequence under the draft spec",
+			src:      `"\u{}"`,
+			opts:     []gogqllexer.Option{gogqllexer.WithSpec(gogqllexer.Draft)},
+			wantCode: gogqllexer.ErrInvalidEscapeSequence,
+			wantHint: `the escape sequences are \" \\ \/ \b \f \n \r \t, \uXXXX and \u{X...}`,
+		},
+		{
+			name:     "unexpected character",
+			src:      "~",
+			wantCode: gogqllexer.ErrUnexpectedCharacter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lex(gogqllexer.NewSource("f", tt.src), tt.opts...)
+
+			if assert.NotEmpty(t, got) {
+				assert.Equal(t, tt.wantCode, got[0].Code)
+				assert.Equal(t, tt.wantHint, got[0].Hint)
+			}
+		})
+	}
+}
+
+func TestLex_MultipleErrors(t *testing.T) {
+	got := Lex(gogqllexer.NewSource("f", "{ a(x: 01, y: \"\\q\") ~ b }"))
+
+	codes := make([]gogqllexer.ErrorCode, 0, len(got))
+	for _, d := range got {
+		codes = append(codes, d.Code)
+	}
+	assert.Equal(t, []gogqllexer.ErrorCode{
+		gogqllexer.ErrLeadingZero,
+		gogqllexer.ErrInvalidEscapeSequence,
+		gogqllexer.ErrUnexpectedCharacter,
+	}, codes)
+}
+
+func TestNew(t *testing.T) {
+	source := gogqllexer.NewSource("f", "{ a(x: 01, y: \"\\q\") ~ b }")
+	l := source.Lexer(gogqllexer.WithErrorRecovery())
+
+	var got []Diagnostic
+	for tok := l.NextToken(); tok.Kind != gogqllexer.EOF; tok = l.NextToken() {
+		if err, ok := l.Err().(*gogqllexer.LexError); ok {
+			got = append(got, New(source, err))
+		}
+	}
+	assert.Equal(t, Lex(source), got)
+}
+
+func TestWriteText(t *testing.T) {
+	diagnostics := append(
+		Lex(gogqllexer.NewSource("query.graphql", "query {\n  a(x: 01)\n  ..b\n}")),
+		Lex(gogqllexer.NewSource("schema.graphql", "type T { f: ~ }"))...,
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteText(&buf, diagnostics))
+	assert.Equal(t, "error[LeadingZero]: unexpected digit after 0\n"+
+		" --> query.graphql:2:8\n"+
+		"2 |   a(x: 01)\n"+
+		"  |        ^^\n"+
+		"  = hint: leading zeros are not allowed\n"+
+		"\n"+
+		"error[IncompleteSpread]: expected \"...\"\n"+
+		" --> query.graphql:3:3\n"+
+		"3 |   ..b\n"+
+		"  |   ^^\n"+
+		"  = hint: did you mean `...`?\n"+
+		"\n"+
+		"error[UnexpectedCharacter]: unexpected character '~'\n"+
+		" --> schema.graphql:1:13\n"+
+		"1 | type T { f: ~ }\n"+
+		"  |             ^\n"+
+		"\n"+
+		"3 errors\n", buf.String())
+}
+
+func TestWriteText_NoDiagnostics(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteText(&buf, Lex(gogqllexer.NewSource("f", "{ a }"))))
+	assert.Empty(t, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteJSON(&buf, Lex(gogqllexer.NewSource("q.graphql", "{\n  a(x: 01) }"))))
+	assert.JSONEq(t, `[
+		{
+			"file": "q.graphql",
+			"code": "LeadingZero",
+			"message": "unexpected digit after 0",
+			"hint": "leading zeros are not allowed",
+			"line": 2, "column": 8, "offset": 9,
+			"endLine": 2, "endColumn": 10, "endOffset": 11
+		}
+	]`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteJSON(&buf, nil))
+	assert.JSONEq(t, `[]`, buf.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	diagnostics := Lex(gogqllexer.NewSource("q.graphql", "{ a(s: \"\U0001F600\\q\") b(x: 01, y: 02) }"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSARIF(&buf, diagnostics))
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "2.1.0", got["version"])
+
+	run := got["runs"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"id": "InvalidEscapeSequence"},
+		map[string]any{"id": "LeadingZero"},
+	}, run["tool"].(map[string]any)["driver"].(map[string]any)["rules"])
+
+	results := run["results"].([]any)
+	assert.Len(t, results, 3)
+	assert.Equal(t, map[string]any{
+		"ruleId":  "InvalidEscapeSequence",
+		"level":   "error",
+		"message": map[string]any{"text": `invalid escape sequence "\q"`},
+		"locations": []any{map[string]any{
+			"physicalLocation": map[string]any{
+				"artifactLocation": map[string]any{"uri": "q.graphql"},
+				// the emoji is two UTF-16 code units
+				"region": map[string]any{"startLine": 1.0, "startColumn": 8.0, "endLine": 1.0, "endColumn": 14.0},
+			},
+		}},
+		"properties": map[string]any{"hint": `the escape sequences are \" \\ \/ \b \f \n \r \t and \uXXXX`},
+	}, results[0])
+}
+
+func TestWriteSARIF_Stdin(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSARIF(&buf, Lex(gogqllexer.NewSource("<stdin>", "~"))))
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	result := got["runs"].([]any)[0].(map[string]any)["results"].([]any)[0].(map[string]any)
+	assert.NotContains(t, result, "locations")
+}
+
+func TestArtifactURI(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "q.graphql", want: "q.graphql", wantOK: true},
+		{name: "dir/my query.graphql", want: "dir/my%20query.graphql", wantOK: true},
+		{name: `dir\q.graphql`, want: "dir/q.graphql", wantOK: true},
+		{name: "/home/u/q.graphql", want: "file:///home/u/q.graphql", wantOK: true},
+		{name: `C:\Users\u\q.graphql`, want: "file:///C:/Users/u/q.graphql", wantOK: true},
+		{name: "file:///home/u/q.graphql", want: "file:///home/u/q.graphql", wantOK: true},
+		{name: "<stdin>"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := artifactURI(tt.name)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// WriteSARIF writes the diagnostics as a SARIF 2.1.0 log with a single run, with one rule per error code.
+// Columns count UTF-16 code units, the default of SARIF, and hints are in the "hint" property of results.
+// Source names are written as URIs, see artifactURI.
+func WriteSARIF(w io.Writer, diagnostics []Diagnostic) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "gogqllexer",
+				InformationURI: "https://github.com/Sntree2mi8/gogqllexer",
+				Rules:          []sarifRule{},
+			},
+		},
+		ColumnKind: "utf16CodeUnits",
+		Results:    []sarifResult{},
+	}
+
+	rules := make(map[string]bool)
+	for _, d := range diagnostics {
+		id := d.Code.String()
+		if !rules[id] {
+			rules[id] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+		}
+
+		result := sarifResult{
+			RuleID:  id,
+			Level:   "error",
+			Message: sarifMessage{Text: d.Message},
+		}
+		// a physical location needs an artifact, so a result in standard input has no location
+		if uri, ok := artifactURI(d.Source.Name); ok {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+					Region: sarifRegion{
+						StartLine:   d.Span.Start.Line,
+						StartColumn: d.Span.Start.UTF16Column,
+						EndLine:     d.Span.End.Line,
+						EndColumn:   d.Span.End.UTF16Column,
+					},
+				},
+			}}
+		}
+		if d.Hint != "" {
+			result.Properties = map[string]string{"hint": d.Hint}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// artifactURI returns the URI of the artifact named name: name itself if it is already an absolute URI,
+// a file URI for an absolute path, or a relative reference for a relative path.
+// It reports false for names that are not files, such as "<stdin>".
+func artifactURI(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, "<") {
+		return "", false
+	}
+	// a scheme is at least two characters, so that the drive of a Windows path is not taken for one
+	if u, err := url.Parse(name); err == nil && len(u.Scheme) > 1 {
+		return name, true
+	}
+
+	p := strings.ReplaceAll(name, "\\", "/")
+	switch {
+	case strings.HasPrefix(p, "/"):
+		return (&url.URL{Scheme: "file", Path: path.Clean(p)}).String(), true
+	case len(p) > 2 && p[1] == ':' && p[2] == '/':
+		return (&url.URL{Scheme: "file", Path: "/" + path.Clean(p)}).String(), true
+	default:
+		return (&url.URL{Path: path.Clean(p)}).String(), true
+	}
+}
@@ -0,0 +1,58 @@
+package gogqllexer
+
+import "fmt"
+
+type ErrorCode int
+
+const (
+	ErrUnexpectedCharacter ErrorCode = iota + 1
+	ErrUnterminatedString
+	ErrInvalidCharacterInString
+	ErrInvalidEscapeSequence
+	ErrLeadingZero
+	ErrInvalidNumber
+	ErrIncompleteSpread
+	ErrLimitExceeded
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	ErrUnexpectedCharacter:      "UnexpectedCharacter",
+	ErrUnterminatedString:       "UnterminatedString",
+	ErrInvalidCharacterInString: "InvalidCharacterInString",
+	ErrInvalidEscapeSequence:    "InvalidEscapeSequence",
+	ErrLeadingZero:              "LeadingZero",
+	ErrInvalidNumber:            "InvalidNumber",
+	ErrIncompleteSpread:         "IncompleteSpread",
+	ErrLimitExceeded:            "LimitExceeded",
+}
+
+func (c ErrorCode) String() string {
+	if s, ok := errorCodeNames[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("ErrorCode(%d)", int(c))
+}
+
+// LexError describes why the lexer produced an Invalid token.
+type LexError struct {
+	Code    ErrorCode
+	Message string
+	// Text is the source text consumed before the error was detected.
+	Text     string
+	Position Position
+	Span     Span
+	// Skipped is the range discarded to resynchronize when error recovery is enabled.
+	Skipped Span
+	// Spec is the spec edition whose lexical rules the input broke.
+	Spec Spec
+}
+
+// Error returns the message prefixed with the line and byte column of the Invalid token.
+func (e *LexError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Span.Start.Line, e.Span.Start.Column, e.Message)
+}
+
+// Is reports whether e is an ErrLimitExceeded error when target is ErrLimit.
+func (e *LexError) Is(target error) bool {
+	return target == ErrLimit && e.Code == ErrLimitExceeded
+}
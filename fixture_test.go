@@ -0,0 +1,163 @@
+package gogqllexer
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixture is the content of a .tokens.json file, see testdata/fixtures/README.md.
+type fixture struct {
+	Options *fixtureOptions `json:"options,omitempty"`
+	Tokens  []fixtureToken  `json:"tokens"`
+}
+
+type fixtureOptions struct {
+	Comments      bool   `json:"comments,omitempty"`
+	ErrorRecovery bool   `json:"errorRecovery,omitempty"`
+	UnicodeNames  bool   `json:"unicodeNames,omitempty"`
+	Spec          string `json:"spec,omitempty"`
+}
+
+type fixtureToken struct {
+	Kind      Kind   `json:"kind"`
+	Value     string `json:"value,omitempty"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Offset    int    `json:"offset"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	EndOffset int    `json:"endOffset"`
+	Error     string `json:"error,omitempty"`
+	// ErrorLine and ErrorColumn are where graphql-js reports the error of an Invalid token.
+	ErrorLine   int `json:"errorLine,omitempty"`
+	ErrorColumn int `json:"errorColumn,omitempty"`
+}
+
+func (o *fixtureOptions) lexerOptions(t *testing.T) []Option {
+	if o == nil {
+		return nil
+	}
+
+	var opts []Option
+	if o.Comments {
+		opts = append(opts, WithComments())
+	}
+	if o.ErrorRecovery {
+		opts = append(opts, WithErrorRecovery())
+	}
+	if o.UnicodeNames {
+		opts = append(opts, WithUnicodeNames())
+	}
+	switch o.Spec {
+	case "", "october2021":
+	case "draft":
+		opts = append(opts, WithSpec(Draft))
+	default:
+		t.Fatalf("unknown spec %q", o.Spec)
+	}
+
+	return opts
+}
+
+// TestFixtures lexes every .graphql file in testdata/fixtures and compares the tokens
+// with the .tokens.json file next to it.
+func TestFixtures(t *testing.T) {
+	err := filepath.WalkDir("testdata/fixtures", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, ".tokens.json") {
+			if _, err := os.Stat(strings.TrimSuffix(path, ".tokens.json") + ".graphql"); err != nil {
+				t.Errorf("%s has no source: %v", path, err)
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".graphql" {
+			return nil
+		}
+
+		name := strings.TrimSuffix(filepath.ToSlash(path), ".graphql")
+		t.Run(strings.TrimPrefix(name, "testdata/fixtures/"), func(t *testing.T) {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tokensPath := strings.TrimSuffix(path, ".graphql") + ".tokens.json"
+			b, err := os.ReadFile(tokensPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want fixture
+			if err := json.Unmarshal(b, &want); err != nil {
+				t.Fatalf("%s: %v", tokensPath, err)
+			}
+
+			got := lexFixture(string(src), want.Options.lexerOptions(t))
+			checkErrorLocations(t, want.Tokens, got)
+			assert.Equal(t, want.Tokens, got)
+		})
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func lexFixture(src string, opts []Option) []fixtureToken {
+	l := New(strings.NewReader(src), opts...)
+
+	tokens := make([]fixtureToken, 0)
+	for {
+		tok := l.NextToken()
+		ft := fixtureToken{
+			Kind:      tok.Kind,
+			Value:     tok.Value,
+			Line:      tok.Span.Start.Line,
+			Column:    tok.Span.Start.Column,
+			Offset:    tok.Span.Start.Offset,
+			EndLine:   tok.Span.End.Line,
+			EndColumn: tok.Span.End.Column,
+			EndOffset: tok.Span.End.Offset,
+		}
+		if err, ok := l.Err().(*LexError); ok {
+			ft.Error = err.Code.String()
+		}
+		tokens = append(tokens, ft)
+
+		if tok.Kind == EOF || tok.Kind == Invalid && !l.recoverErrors {
+			return tokens
+		}
+	}
+}
+
+// checkErrorLocations checks that the error locations in want lie within the Invalid tokens of got.
+// The end of an Invalid token may be left out of want, it is then taken from got,
+// so that only the start and the error location are compared.
+func checkErrorLocations(t *testing.T, want, got []fixtureToken) {
+	t.Helper()
+
+	for i := range want {
+		w := &want[i]
+		if w.Kind != Invalid || i >= len(got) || got[i].Kind != Invalid {
+			continue
+		}
+		g := got[i]
+		if w.ErrorLine != 0 {
+			at := [2]int{w.ErrorLine, w.ErrorColumn}
+			if less(at, [2]int{g.Line, g.Column}) || less([2]int{g.EndLine, g.EndColumn}, at) {
+				t.Errorf("token %d: error at %v is outside of the Invalid token from %d:%d to %d:%d", i, at, g.Line, g.Column, g.EndLine, g.EndColumn)
+			}
+			w.ErrorLine, w.ErrorColumn = 0, 0
+		}
+		if w.EndLine == 0 {
+			w.EndLine, w.EndColumn, w.EndOffset = g.EndLine, g.EndColumn, g.EndOffset
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package gogqllexer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var fuzzSeeds = []string{
+	"",
+	"query Q($id: ID! = -1.5e3) {\r\n  a: f(id: $id) @d { ...F }\r\n}\n",
+	"type T implements A & B { f(x: [Int!] = [1, 2]): String }\nunion U = | A | B",
+	"\"s\\u00e9\\u{1F600}\\uD83D\\uDE00\" \"\"\"\n  block \\\"\"\"\n\"\"\"",
+	"# comment\r\n{ a }",
+	"0 -0 1.5 1e10 1.5E-3 01 1. .5 1.e 0x1 1_",
+	"\"open\n\"\\q\" \"\\u12\" \"\"\"open",
+	"\uFEFF{ a }\t,\x00 \x01 ? ..",
+	"\U0001F600 é _x1",
+}
+
+// FuzzLexer_NextToken checks invariants that hold for any input.
+func FuzzLexer_NextToken(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	for _, c := range loadGraphQLJSCases(f) {
+		f.Add(c.Source)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		checkTokenInvariants(t, src, New(strings.NewReader(src)))
+		checkTokenInvariants(t, src, New(strings.NewReader(src), WithComments(), WithErrorRecovery()))
+		checkTokenInvariants(t, src, NewFromString(src, WithUnicodeNames(), WithErrorRecovery()))
+		if utf8.ValidString(src) {
+			checkSameTokens(t, New(strings.NewReader(src), WithErrorRecovery()), NewFromString(src, WithErrorRecovery()))
+		}
+	})
+}
+
+// checkSameTokens checks that a lexer reading a RuneScanner and one created by NewFromString return the same tokens and errors.
+func checkSameTokens(t *testing.T, want, got *Lexer) {
+	t.Helper()
+
+	for i := 0; ; i++ {
+		wantTok, gotTok := want.NextToken(), got.NextToken()
+		if wantTok != gotTok {
+			t.Fatalf("token %d is %+v, reading a RuneScanner it is %+v", i, gotTok, wantTok)
+		}
+		if !reflect.DeepEqual(want.Err(), got.Err()) {
+			t.Fatalf("token %d has error %#v, reading a RuneScanner it is %#v", i, got.Err(), want.Err())
+		}
+		if wantTok.Kind == EOF {
+			return
+		}
+	}
+}
+
+func checkTokenInvariants(t *testing.T, src string, l *Lexer) {
+	t.Helper()
+
+	// a RuneScanner reads invalid UTF-8 as utf8.RuneError, so the text of such tokens is not the source text
+	valid := utf8.ValidString(src)
+	prev := Location{Line: 1, Column: 1, UTF16Column: 1}
+	extents := 0
+	// every token but EOF consumes at least one byte, so EOF comes after at most len(src) tokens
+	for i := 0; i <= len(src); i++ {
+		tok := l.NextToken()
+		span := tok.Span
+
+		if span.Start.Offset < prev.Offset || span.Start.Line < prev.Line {
+			t.Fatalf("token %d %v starts at %+v, before the end of the previous token at %+v", i, tok.Kind, span.Start, prev)
+		}
+		if span.End.Offset > len(src) {
+			t.Fatalf("token %d %v has span [%d, %d) outside the input of %d bytes", i, tok.Kind, span.Start.Offset, span.End.Offset, len(src))
+		}
+		if tok.Kind != EOF && span.End.Offset <= span.Start.Offset {
+			t.Fatalf("token %d %v has span [%d, %d), it must consume input", i, tok.Kind, span.Start.Offset, span.End.Offset)
+		}
+		if valid && tok.Kind.IsValue() && tok.Value != src[span.Start.Offset:span.End.Offset] {
+			t.Fatalf("token %d %v has value %q, source text is %q", i, tok.Kind, tok.Value, src[span.Start.Offset:span.End.Offset])
+		}
+		if valid && tok.Kind != EOF && tok.Position != (Position{Line: span.Start.Line, Start: span.Start.Offset + 1}) {
+			t.Fatalf("token %d %v has position %+v, span starts at %+v", i, tok.Kind, tok.Position, span.Start)
+		}
+		if tok.Kind == Invalid {
+			var err *LexError
+			if !errors.As(l.Err(), &err) || err.Span != span {
+				t.Fatalf("token %d is Invalid at %+v with error %v", i, span, l.Err())
+			}
+		}
+		extents += span.End.Offset - span.Start.Offset
+		if extents > len(src) {
+			t.Fatalf("tokens cover %d bytes of an input of %d bytes", extents, len(src))
+		}
+		prev = span.End
+
+		if tok.Kind == EOF {
+			if span.End.Offset != len(src) {
+				t.Fatalf("EOF at %d, input is %d bytes", span.End.Offset, len(src))
+			}
+			// EOF is terminal
+			for j := 0; j < 2; j++ {
+				if next := l.NextToken(); next.Kind != EOF || next.Span != span {
+					t.Fatalf("%v at %+v after EOF at %+v", next.Kind, next.Span, span)
+				}
+			}
+			return
+		}
+	}
+	t.Fatalf("no EOF after %d tokens", len(src)+1)
+}
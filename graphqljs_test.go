@@ -0,0 +1,161 @@
+package gogqllexer
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// graphqljsCase is a token stream in the format of graphql-js, see testdata/graphql-js/README.md.
+type graphqljsCase struct {
+	Source string           `json:"source"`
+	Tokens []graphqljsToken `json:"tokens"`
+	Error  *graphqljsError  `json:"error,omitempty"`
+}
+
+type graphqljsToken struct {
+	Kind   string  `json:"kind"`
+	Start  int     `json:"start"`
+	End    int     `json:"end"`
+	Line   int     `json:"line"`
+	Column int     `json:"column"`
+	Value  *string `json:"value,omitempty"`
+}
+
+type graphqljsError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// graphqljsErrorCodes maps the start of graphql-js syntax error messages to the codes of the same errors.
+var graphqljsErrorCodes = []struct {
+	prefix string
+	codes  []ErrorCode
+}{
+	{"Syntax Error: Unterminated string.", []ErrorCode{ErrUnterminatedString}},
+	{"Syntax Error: Invalid character within String", []ErrorCode{ErrInvalidCharacterInString}},
+	{"Syntax Error: Invalid character escape sequence", []ErrorCode{ErrInvalidEscapeSequence}},
+	{"Syntax Error: Invalid Unicode escape sequence", []ErrorCode{ErrInvalidEscapeSequence}},
+	{"Syntax Error: Invalid number, unexpected digit after 0", []ErrorCode{ErrLeadingZero}},
+	{"Syntax Error: Invalid number", []ErrorCode{ErrInvalidNumber}},
+	{"Syntax Error: Unexpected", []ErrorCode{ErrUnexpectedCharacter, ErrIncompleteSpread}},
+}
+
+// graphqljsDifferences are the sources that graphql-js rejects and the lexer accepts, with the reason.
+// The lexer is checked to accept them, so that an entry does not outlive the difference.
+var graphqljsDifferences = map[string]string{
+	`"too long \u{000000000} esc"`: "graphql-js limits variable-width escapes to 8 hex digits, the spec allows leading zeros",
+}
+
+// TestLexer_GraphQLJS compares the tokens and errors with those of graphql-js for the same sources.
+// graphql-js 16 implements the draft spec, skips comments, counts offsets and columns in UTF-16 code units
+// and decodes string values.
+func TestLexer_GraphQLJS(t *testing.T) {
+	for _, c := range loadGraphQLJSCases(t) {
+		t.Run(c.Source, func(t *testing.T) {
+			l := New(strings.NewReader(c.Source), WithSpec(Draft))
+			if reason, ok := graphqljsDifferences[c.Source]; ok {
+				for tok := l.NextToken(); tok.Kind != EOF; tok = l.NextToken() {
+					if tok.Kind == Invalid {
+						t.Fatalf("graphql-js and the lexer both fail with %v, the difference %q no longer holds", l.Err(), reason)
+					}
+				}
+				return
+			}
+
+			got := make([]graphqljsToken, 0, len(c.Tokens))
+			for range c.Tokens {
+				tok := l.NextToken()
+				got = append(got, toGraphQLJSToken(c.Source, tok))
+				if tok.Kind == Invalid {
+					t.Errorf("unexpected error: %v", l.Err())
+					break
+				}
+			}
+			assert.Equal(t, c.Tokens, got)
+
+			if c.Error == nil {
+				return
+			}
+			tok := l.NextToken()
+			if !assert.Equal(t, Invalid, tok.Kind, "want error %q", c.Error.Message) {
+				return
+			}
+			// graphql-js reports the offending character, which lies within the Invalid token
+			start := [2]int{tok.Span.Start.Line, tok.Span.Start.UTF16Column}
+			at := [2]int{c.Error.Line, c.Error.Column}
+			end := [2]int{tok.Span.End.Line, tok.Span.End.UTF16Column}
+			if less(at, start) || less(end, at) {
+				t.Errorf("error %q at %v is outside of the Invalid token from %v to %v", c.Error.Message, at, start, end)
+			}
+			var codes []ErrorCode
+			for _, e := range graphqljsErrorCodes {
+				if strings.HasPrefix(c.Error.Message, e.prefix) {
+					codes = e.codes
+					break
+				}
+			}
+			var lexErr *LexError
+			if assert.ErrorAs(t, l.Err(), &lexErr) {
+				assert.Contains(t, codes, lexErr.Code, "want error %q, got %v", c.Error.Message, lexErr)
+			}
+		})
+	}
+}
+
+func loadGraphQLJSCases(tb testing.TB) []graphqljsCase {
+	tb.Helper()
+
+	b, err := os.ReadFile("testdata/graphql-js/lexer.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var cases []graphqljsCase
+	if err := json.Unmarshal(b, &cases); err != nil {
+		tb.Fatal(err)
+	}
+
+	return cases
+}
+
+func toGraphQLJSToken(src string, tok Token) graphqljsToken {
+	got := graphqljsToken{
+		Kind:   tok.Kind.String(),
+		Start:  utf16Offset(src, tok.Span.Start.Offset),
+		End:    utf16Offset(src, tok.Span.End.Offset),
+		Line:   tok.Span.Start.Line,
+		Column: tok.Span.Start.UTF16Column,
+	}
+	switch {
+	case tok.Kind == EOF:
+		got.Kind = "<EOF>"
+	case tok.Kind.IsPunctuator():
+		got.Kind = tok.Kind.Text()
+	case tok.Kind == String || tok.Kind == BlockString:
+		v, _ := tok.StringValue()
+		got.Value = &v
+	case tok.Kind.IsValue():
+		v := tok.Value
+		got.Value = &v
+	}
+
+	return got
+}
+
+// utf16Offset returns the offset in UTF-16 code units of the byte offset in src.
+func utf16Offset(src string, offset int) int {
+	n := 0
+	for _, r := range src[:offset] {
+		n += utf16Len(r)
+	}
+
+	return n
+}
+
+func less(a, b [2]int) bool {
+	return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+}
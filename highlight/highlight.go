@@ -0,0 +1,319 @@
+package highlight
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/Sntree2mi8/gogqllexer"
+)
+
+type Class int
+
+const (
+	Name Class = iota
+	Keyword
+	Variable
+	Directive
+	String
+	Number
+	Punctuator
+	Comment
+	Invalid
+)
+
+var classNames = [...]string{
+	Name:       "name",
+	Keyword:    "keyword",
+	Variable:   "variable",
+	Directive:  "directive",
+	String:     "string",
+	Number:     "number",
+	Punctuator: "punctuator",
+	Comment:    "comment",
+	Invalid:    "invalid",
+}
+
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return fmt.Sprintf("Class(%d)", int(c))
+	}
+	return classNames[c]
+}
+
+// unnamed are the definition keywords not followed by a name: schema {, directive @d, extend type and
+// repeatable on. Every other definition keyword is followed by a name, which is not a keyword even if spelled like one.
+var unnamed = map[string]bool{
+	"schema":     true,
+	"directive":  true,
+	"extend":     true,
+	"repeatable": true,
+}
+
+// isDefinitionKeyword reports whether name is a keyword at the top level of a document.
+func isDefinitionKeyword(name string) bool {
+	k, ok := gogqllexer.Keywords[name]
+	return ok && k != gogqllexer.ValueKeyword
+}
+
+// Token is a lexed token and its highlighting class.
+type Token struct {
+	gogqllexer.Token
+	Class Class
+}
+
+// Classify lexes src with comments and error recovery enabled and classifies every token.
+// Names are classified as keywords by position, so that a field named type or an argument named on is a Name:
+// definition keywords at the top level, on after "...", true, false and null as values,
+// and operation types in a schema definition.
+func Classify(src string, opts ...gogqllexer.Option) []Token {
+	opts = append([]gogqllexer.Option{gogqllexer.WithComments(), gogqllexer.WithErrorRecovery()}, opts...)
+	l := gogqllexer.NewFromString(src, opts...)
+
+	var (
+		tokens []Token
+		c      classifier
+	)
+	for {
+		t := l.NextToken()
+		if t.Kind == gogqllexer.EOF {
+			return tokens
+		}
+		tokens = append(tokens, c.classify(t))
+	}
+}
+
+// classifier tracks just enough of the syntax of a document to classify its tokens in order.
+type classifier struct {
+	// prev holds the last three tokens other than comments, the most recent first.
+	prev [3]Token
+	// brackets holds the brackets that are open, the innermost last.
+	brackets []bracket
+	// definition is the keyword that starts the current top-level definition, or "" for a query shorthand.
+	definition string
+}
+
+type bracket struct {
+	open gogqllexer.Kind
+	// value reports whether the bracket opens a list or object value, or parentheses holding arguments.
+	value bool
+}
+
+func (c *classifier) classify(t gogqllexer.Token) Token {
+	tok := Token{
+		Token: t,
+		Class: c.class(t),
+	}
+	if t.Kind == gogqllexer.Comment {
+		return tok
+	}
+
+	switch t.Kind {
+	case gogqllexer.BraceL, gogqllexer.BracketL:
+		c.brackets = append(c.brackets, bracket{open: t.Kind, value: c.inValue()})
+	case gogqllexer.ParenL:
+		c.brackets = append(c.brackets, bracket{open: t.Kind, value: !c.inArgumentDefinitions()})
+	case gogqllexer.BraceR, gogqllexer.BracketR, gogqllexer.ParenR:
+		if len(c.brackets) > 0 {
+			c.brackets = c.brackets[:len(c.brackets)-1]
+			if len(c.brackets) == 0 {
+				c.definition = ""
+			}
+		}
+	case gogqllexer.Name:
+		// modifiers are part of the header of the current definition
+		if len(c.brackets) == 0 && tok.Class == Keyword && gogqllexer.Keywords[t.Value] != gogqllexer.ModifierKeyword {
+			c.definition = t.Value
+		}
+	}
+	c.prev = [3]Token{tok, c.prev[0], c.prev[1]}
+
+	return tok
+}
+
+func (c *classifier) class(t gogqllexer.Token) Class {
+	switch t.Kind {
+	case gogqllexer.Name:
+		switch {
+		case c.prev[0].Kind == gogqllexer.Dollar:
+			return Variable
+		case c.prev[0].Kind == gogqllexer.At:
+			return Directive
+		case c.isKeyword(t.Value):
+			return Keyword
+		default:
+			return Name
+		}
+	case gogqllexer.Dollar:
+		return Variable
+	case gogqllexer.At:
+		return Directive
+	case gogqllexer.String, gogqllexer.BlockString:
+		return String
+	case gogqllexer.Int, gogqllexer.Float:
+		return Number
+	case gogqllexer.Comment:
+		return Comment
+	case gogqllexer.Invalid:
+		return Invalid
+	default:
+		return Punctuator
+	}
+}
+
+func (c *classifier) isKeyword(name string) bool {
+	prev := c.prev[0]
+	if len(c.brackets) == 0 {
+		// a name is expected after "type", "=", "|" and the like, e.g. in union U = type
+		named := prev.Class == Keyword && !unnamed[prev.Value] ||
+			prev.Kind == gogqllexer.Amp || prev.Kind == gogqllexer.Equal || prev.Kind == gogqllexer.Pipe
+		return isDefinitionKeyword(name) && !named
+	}
+
+	switch gogqllexer.Keywords[name] {
+	case gogqllexer.ModifierKeyword:
+		return name == "on" && prev.Kind == gogqllexer.Spread
+	case gogqllexer.ValueKeyword:
+		return c.inValue()
+	case gogqllexer.OperationKeyword:
+		// the operation types of schema { query: Query }
+		return len(c.brackets) == 1 && c.definition == "schema" && prev.Kind != gogqllexer.Colon
+	default:
+		return false
+	}
+}
+
+// inValue reports whether the next token is a value, or starts one.
+func (c *classifier) inValue() bool {
+	if len(c.brackets) == 0 {
+		return false
+	}
+
+	inner := c.brackets[len(c.brackets)-1]
+	switch c.prev[0].Kind {
+	case gogqllexer.Equal:
+		// a default value
+		return true
+	case gogqllexer.Colon:
+		// an argument or an object field, rather than a type or a field after an alias
+		return inner.value
+	default:
+		return inner.open == gogqllexer.BracketL && inner.value
+	}
+}
+
+// inArgumentDefinitions reports whether parentheses opened next hold variable or argument definitions,
+// whose types are not values.
+func (c *classifier) inArgumentDefinitions() bool {
+	if c.prev[1].Kind == gogqllexer.At {
+		// directive @d( defines arguments, @d( elsewhere passes them
+		return len(c.brackets) == 0 && c.prev[2].Class == Keyword && c.prev[2].Value == "directive"
+	}
+
+	return len(c.brackets) == 0 || gogqllexer.Keywords[c.definition] == gogqllexer.TypeSystemKeyword
+}
+
+// ANSIColors are the SGR parameters written around each class by WriteANSI.
+// A class without parameters is written uncolored.
+var ANSIColors = map[Class]string{
+	Keyword:   "35",
+	Variable:  "36",
+	Directive: "33",
+	String:    "32",
+	Number:    "34",
+	Comment:   "90",
+	Invalid:   "31;4",
+}
+
+// WriteANSI writes src to w with ANSI escape sequences coloring its tokens.
+func WriteANSI(w io.Writer, src string) error {
+	return write(w, src, func(class Class, text string) string {
+		color, ok := ANSIColors[class]
+		if !ok {
+			return text
+		}
+		return "\x1b[" + color + "m" + text + "\x1b[0m"
+	}, func(text string) string {
+		return text
+	})
+}
+
+// WriteHTML writes src to w HTML-escaped, with every token wrapped in a span
+// whose class is "gql-" followed by the name of its Class, e.g. <span class="gql-keyword">query</span>.
+func WriteHTML(w io.Writer, src string) error {
+	return write(w, src, func(class Class, text string) string {
+		return `<span class="gql-` + class.String() + `">` + html.EscapeString(text) + `</span>`
+	}, html.EscapeString)
+}
+
+func write(w io.Writer, src string, token func(class Class, text string) string, between func(text string) string) error {
+	offset := 0
+	for _, t := range Classify(src) {
+		if _, err := io.WriteString(w, between(src[offset:t.Span.Start.Offset])); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, token(t.Class, src[t.Span.Start.Offset:t.Span.End.Offset])); err != nil {
+			return err
+		}
+		offset = t.Span.End.Offset
+	}
+	_, err := io.WriteString(w, between(src[offset:]))
+	return err
+}
+
+// SemanticTokenTypes is the LSP semantic tokens legend of SemanticTokens, indexed by Class.
+var SemanticTokenTypes = []string{
+	Name:       "property",
+	Keyword:    "keyword",
+	Variable:   "variable",
+	Directive:  "decorator",
+	String:     "string",
+	Number:     "number",
+	Punctuator: "operator",
+	Comment:    "comment",
+}
+
+// SemanticTokens returns the LSP semantic tokens of src in the relative encoding of
+// textDocument/semanticTokens/full: five integers per token with 0-based lines and UTF-16 characters.
+// Tokens spanning several lines are split at line terminators and Invalid tokens are left out.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+func SemanticTokens(src string) []uint32 {
+	var (
+		data               []uint32
+		prevLine, prevChar int
+	)
+	emit := func(line, char, length int, class Class) {
+		if length == 0 {
+			return
+		}
+		deltaChar := char
+		if line == prevLine {
+			deltaChar = char - prevChar
+		}
+		data = append(data, uint32(line-prevLine), uint32(deltaChar), uint32(length), uint32(class), 0)
+		prevLine, prevChar = line, char
+	}
+
+	source := gogqllexer.NewSource("", src)
+	for _, t := range Classify(src) {
+		if t.Class == Invalid {
+			continue
+		}
+
+		line, char := t.Span.Start.Line-1, t.Span.Start.UTF16Column-1
+		for offset := t.Span.Start.Offset; offset < t.Span.End.Offset; offset++ {
+			if b := src[offset]; b != '\n' && b != '\r' {
+				continue
+			}
+
+			emit(line, char, source.Location(offset).UTF16Column-1-char, t.Class)
+			if src[offset] == '\r' && offset+1 < t.Span.End.Offset && src[offset+1] == '\n' {
+				offset++
+			}
+			line, char = line+1, 0
+		}
+		emit(line, char, t.Span.End.UTF16Column-1-char, t.Class)
+	}
+
+	return data
+}
@@ -0,0 +1,147 @@
+package highlight
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	type class struct {
+		Class Class
+		Text  string
+	}
+
+	src := "query Q($v: Int = 1.5) @dir { f(a: true, b: \"s\") # c\n ... on T { on } } ?"
+	want := []class{
+		{Keyword, "query"},
+		{Name, "Q"},
+		{Punctuator, "("},
+		{Variable, "$"},
+		{Variable, "v"},
+		{Punctuator, ":"},
+		{Name, "Int"},
+		{Punctuator, "="},
+		{Number, "1.5"},
+		{Punctuator, ")"},
+		{Directive, "@"},
+		{Directive, "dir"},
+		{Punctuator, "{"},
+		{Name, "f"},
+		{Punctuator, "("},
+		{Name, "a"},
+		{Punctuator, ":"},
+		{Keyword, "true"},
+		{Name, "b"},
+		{Punctuator, ":"},
+		{String, `"s"`},
+		{Punctuator, ")"},
+		{Comment, "# c"},
+		{Punctuator, "..."},
+		{Keyword, "on"},
+		{Name, "T"},
+		{Punctuator, "{"},
+		{Name, "on"},
+		{Punctuator, "}"},
+		{Punctuator, "}"},
+		{Invalid, "?"},
+	}
+
+	var got []class
+	for _, tok := range Classify(src) {
+		got = append(got, class{tok.Class, src[tok.Span.Start.Offset:tok.Span.End.Offset]})
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestClassify_Keywords(t *testing.T) {
+	tests := []struct {
+		name         string
+		src          string
+		wantKeywords []string
+	}{
+		{
+			name:         "fields and aliases spelled like keywords",
+			src:          "query { type input: on schema { null } ... on T { fragment } }",
+			wantKeywords: []string{"query", "on"},
+		},
+		{
+			name:         "arguments and object fields spelled like keywords",
+			src:          "{ f(type: true, on: {input: null, list: [false, enum]}) }",
+			wantKeywords: []string{"true", "null", "false"},
+		},
+		{
+			name:         "variable definitions",
+			src:          "query Q($a: null = null, $b: [true] = [true]) { a }",
+			wantKeywords: []string{"query", "null", "true"},
+		},
+		{
+			name:         "fragment definition",
+			src:          "fragment on on on { on }",
+			wantKeywords: []string{"fragment", "on"},
+		},
+		{
+			name:         "type definitions",
+			src:          "extend type type implements input & on @d(if: true) { type(on: Boolean = false): query }\nunion U = type | on\nscalar S\ninput I { null: Int = null }",
+			wantKeywords: []string{"extend", "type", "implements", "true", "false", "union", "scalar", "input", "null"},
+		},
+		{
+			name:         "directive definition",
+			src:          "directive @d(on: Boolean = true) repeatable on FIELD | QUERY\nenum E { true }",
+			wantKeywords: []string{"directive", "true", "repeatable", "on", "enum"},
+		},
+		{
+			name:         "schema definition",
+			src:          "schema { query: query mutation: Mutation }\ntype query { subscription: Int }",
+			wantKeywords: []string{"schema", "query", "mutation", "type"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range Classify(tt.src) {
+				if tok.Class == Keyword {
+					got = append(got, tok.Value)
+				}
+			}
+			assert.Equal(t, tt.wantKeywords, got)
+		})
+	}
+}
+
+func TestWriteANSI(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteANSI(&buf, "query {\n  a(n: 1) @d # c\n}"))
+	assert.Equal(t, "\x1b[35mquery\x1b[0m {\n  a(n: \x1b[34m1\x1b[0m) \x1b[33m@\x1b[0m\x1b[33md\x1b[0m \x1b[90m# c\x1b[0m\n}", buf.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteHTML(&buf, "{ a(s: \"<b>&\") }\n"))
+	assert.Equal(t, `<span class="gql-punctuator">{</span> <span class="gql-name">a</span><span class="gql-punctuator">(</span>`+
+		`<span class="gql-name">s</span><span class="gql-punctuator">:</span> <span class="gql-string">&#34;&lt;b&gt;&amp;&#34;</span>`+
+		`<span class="gql-punctuator">)</span> <span class="gql-punctuator">}</span>`+"\n", buf.String())
+}
+
+func TestSemanticTokens(t *testing.T) {
+	src := "query {\n  a(s: \"\"\"x\n\U0001F600y\"\"\") @d\n}"
+	want := []uint32{
+		0, 0, 5, uint32(Keyword), 0,
+		0, 6, 1, uint32(Punctuator), 0,
+		1, 2, 1, uint32(Name), 0,
+		0, 1, 1, uint32(Punctuator), 0,
+		0, 1, 1, uint32(Name), 0,
+		0, 1, 1, uint32(Punctuator), 0,
+		// the block string is split at the line terminator
+		0, 2, 4, uint32(String), 0,
+		1, 0, 6, uint32(String), 0,
+		0, 6, 1, uint32(Punctuator), 0,
+		0, 2, 1, uint32(Directive), 0,
+		0, 1, 1, uint32(Directive), 0,
+		1, 0, 1, uint32(Punctuator), 0,
+	}
+
+	assert.Equal(t, want, SemanticTokens(src))
+	assert.Equal(t, "decorator", SemanticTokenTypes[Directive])
+}
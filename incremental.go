@@ -0,0 +1,150 @@
+package gogqllexer
+
+import "unicode/utf8"
+
+// Edit replaces the bytes [Start, End) of a source with Text.
+// Start and End are byte offsets in the source before the edit.
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Apply returns src with the edit applied.
+func (e Edit) Apply(src string) string {
+	return src[:e.Start] + e.Text + src[e.End:]
+}
+
+// Relex returns the tokens of src, the source after edit, given the tokens of the source before it.
+// tokens must be the complete token stream lexed with the same options: up to and including EOF,
+// or up to and including the first Invalid token when error recovery is disabled.
+// Only the region from the last token ending before the edit up to the first token that lines up
+// with an old token again is lexed; the tokens outside it are reused with their positions shifted.
+// An edit that opens or closes a block string therefore re-lexes up to the next common token boundary,
+// which may be the end of the source.
+func Relex(tokens []Token, src string, edit Edit, opts ...Option) []Token {
+	relexed, _ := relex(tokens, src, edit, opts...)
+	return relexed
+}
+
+// relex is Relex that also returns the number of tokens it scanned.
+func relex(tokens []Token, src string, edit Edit, opts ...Option) ([]Token, int) {
+	recovering := New(nil, opts...).recoverErrors
+	if !validEdit(tokens, src, edit) {
+		all := lexAll(NewFromString(src, opts...))
+		return all, len(all)
+	}
+	delta := len(edit.Text) - (edit.End - edit.Start)
+
+	// tokens ending before the edit are not affected by it, even by the rune the lexer peeked after them.
+	// Without error recovery the lexer cannot continue after an Invalid token, so it is lexed again.
+	keep := 0
+	for keep < len(tokens) && tokens[keep].Span.End.Offset < edit.Start && (recovering || tokens[keep].Kind != Invalid) {
+		keep++
+	}
+	start := newCursor()
+	if keep > 0 {
+		start.Location = tokens[keep-1].Span.End
+		start.last, _ = utf8.DecodeLastRuneInString(src[:start.Offset])
+	}
+	l := newFromStringAt(src, start, opts...)
+	l.scanned = keep
+	// where a document-wide limit is exceeded depends on everything before it, so the old tokens cannot be reused
+	resync := l.maxBytes == 0 && l.maxTokens == 0
+
+	relexed := append([]Token{}, tokens[:keep]...)
+	old := keep
+	scanned := 0
+	for {
+		t := l.NextToken()
+		scanned++
+
+		// an old token starting after the edit at the same place begins the same suffix of the source
+		for old < len(tokens) && (tokens[old].Span.Start.Offset < edit.End || tokens[old].Span.Start.Offset+delta < t.Span.Start.Offset) {
+			old++
+		}
+		if resync && old < len(tokens) && tokens[old].Span.Start.Offset+delta == t.Span.Start.Offset {
+			return append(relexed, shift(tokens[old:], tokens[old].Span.Start, t.Span.Start)...), scanned
+		}
+
+		relexed = append(relexed, t)
+		if t.Kind == EOF || t.Kind == Invalid && !recovering {
+			return relexed, scanned
+		}
+	}
+}
+
+func validEdit(tokens []Token, src string, edit Edit) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	oldLen := len(src) - len(edit.Text) + edit.End - edit.Start
+	if 0 > edit.Start || edit.Start > edit.End || edit.End > oldLen {
+		return false
+	}
+
+	switch last := tokens[len(tokens)-1]; last.Kind {
+	case EOF:
+		return last.Span.End.Offset == oldLen
+	case Invalid:
+		return last.Span.End.Offset <= oldLen
+	default:
+		return false
+	}
+}
+
+// shift moves tokens so that the location from becomes to.
+// Columns change only on the line of from, the lines after it start at the same columns.
+func shift(tokens []Token, from, to Location) []Token {
+	shifted := make([]Token, len(tokens))
+	for i, t := range tokens {
+		t.Position.Line += to.Line - from.Line
+		t.Position.Start += to.Offset - from.Offset
+		t.Span.Start = shiftLocation(t.Span.Start, from, to)
+		t.Span.End = shiftLocation(t.Span.End, from, to)
+		shifted[i] = t
+	}
+
+	return shifted
+}
+
+func shiftLocation(loc, from, to Location) Location {
+	if loc.Line == from.Line {
+		loc.Column += to.Column - from.Column
+		loc.UTF16Column += to.UTF16Column - from.UTF16Column
+	}
+	loc.Line += to.Line - from.Line
+	loc.Offset += to.Offset - from.Offset
+
+	return loc
+}
+
+// newFromStringAt returns a Lexer for src that starts scanning at the location of c,
+// as if it had already lexed src up to there.
+func newFromStringAt(src string, c cursor, opts ...Option) *Lexer {
+	l := New(nil, opts...)
+	l.src = src
+	l.fromSource = true
+	l.updateEnd()
+	l.cur = c
+	l.prev = c
+	l.synced = c.Offset
+	l.line = c.Line
+	l.startByteIndex = c.Offset
+	l.lexemeStart = c.Offset
+	l.lexemeCursor = c
+
+	return l
+}
+
+// lexAll returns the tokens of l up to and including EOF, or the first Invalid token when error recovery is disabled.
+func lexAll(l *Lexer) []Token {
+	var tokens []Token
+	for {
+		t := l.NextToken()
+		tokens = append(tokens, t)
+		if t.Kind == EOF || t.Kind == Invalid && !l.recoverErrors {
+			return tokens
+		}
+	}
+}
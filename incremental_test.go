@@ -0,0 +1,151 @@
+package gogqllexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelex(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		edit Edit
+		opts []Option
+	}{
+		{
+			name: "rename field",
+			src:  "query Q {\n  a\n  b(x: 1)\n}",
+			edit: Edit{Start: 12, End: 13, Text: "alias: c"},
+		},
+		{
+			name: "extend name",
+			src:  "{ ab cd }",
+			edit: Edit{Start: 4, End: 4, Text: "x"},
+		},
+		{
+			name: "join names",
+			src:  "{ ab cd }",
+			edit: Edit{Start: 4, End: 5, Text: ""},
+		},
+		{
+			name: "insert line before",
+			src:  "{\n  a\n}\n{ b }",
+			edit: Edit{Start: 0, End: 0, Text: "# c\n\n"},
+		},
+		{
+			name: "insert on the line of later tokens",
+			src:  "{ a(x: 1) b }",
+			edit: Edit{Start: 7, End: 8, Text: "12345"},
+		},
+		{
+			name: "open block string",
+			src:  "{ a(x: \"\") b c }\n{ d }",
+			edit: Edit{Start: 8, End: 8, Text: "\"\"\"\"\n"},
+		},
+		{
+			name: "close block string",
+			src:  "{ a(x: \"\"\" b c }\n{ d }",
+			edit: Edit{Start: 11, End: 11, Text: "\"\"\")"},
+		},
+		{
+			name: "break block string closing quotes",
+			src:  "\"\"\"x\ny\"\"\" type T { f: Int }",
+			edit: Edit{Start: 7, End: 8, Text: ""},
+		},
+		{
+			name: "crlf",
+			src:  "{\r\n  a\r\n  b\r\n}",
+			edit: Edit{Start: 3, End: 3, Text: "x\r\n"},
+		},
+		{
+			name: "split crlf",
+			src:  "{ a\r\n b }",
+			edit: Edit{Start: 4, End: 4, Text: " c "},
+		},
+		{
+			name: "multi-byte characters",
+			src:  "{ a(s: \"\U0001F600\") b(s: \"é\") c }",
+			edit: Edit{Start: 8, End: 12, Text: "日本"},
+		},
+		{
+			name: "comment out",
+			src:  "{\n  a\n  b\n}",
+			edit: Edit{Start: 4, End: 4, Text: "# "},
+			opts: []Option{WithComments()},
+		},
+		{
+			name: "error recovery",
+			src:  "{ a(x: 1) b(y: \"ok\") }",
+			edit: Edit{Start: 7, End: 8, Text: "01"},
+			opts: []Option{WithErrorRecovery()},
+		},
+		{
+			name: "unterminate string",
+			src:  "{ a(x: \"s\") }\n{ b }",
+			edit: Edit{Start: 9, End: 10, Text: ""},
+			opts: []Option{WithErrorRecovery()},
+		},
+		{
+			name: "delete everything",
+			src:  "{ a }",
+			edit: Edit{Start: 0, End: 5, Text: ""},
+		},
+		{
+			name: "append",
+			src:  "{ a }",
+			edit: Edit{Start: 5, End: 5, Text: " { b }"},
+		},
+		{
+			name: "insert past max tokens",
+			src:  "{ a b c }",
+			edit: Edit{Start: 2, End: 2, Text: "x "},
+			opts: []Option{WithMaxTokens(5)},
+		},
+		{
+			name: "name past max token bytes",
+			src:  "{ ab cd }",
+			edit: Edit{Start: 4, End: 4, Text: "x"},
+			opts: []Option{WithMaxTokenBytes(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := lexAll(NewFromString(tt.src, tt.opts...))
+			src := tt.edit.Apply(tt.src)
+
+			got := Relex(before, src, tt.edit, tt.opts...)
+
+			assert.Equal(t, lexAll(NewFromString(src, tt.opts...)), got)
+		})
+	}
+}
+
+func TestRelex_Region(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		b.WriteString("type T {\n  f(a: Int = 1): [String!]!\n}\n")
+	}
+	old := b.String()
+	before := lexAll(NewFromString(old))
+
+	// rename the field of the 500th type
+	offset := 500*len("type T {\n  f(a: Int = 1): [String!]!\n}\n") + len("type T {\n  ")
+	edit := Edit{Start: offset, End: offset + 1, Text: "field"}
+	src := edit.Apply(old)
+
+	got, scanned := relex(before, src, edit)
+
+	assert.Equal(t, lexAll(NewFromString(src)), got)
+	assert.Less(t, scanned, 5)
+}
+
+func TestRelex_InvalidEdit(t *testing.T) {
+	before := lexAll(NewFromString("{ a }"))
+
+	// the edit does not match the tokens, so the whole source is lexed
+	got := Relex(before, "{ b c }", Edit{Start: 2, End: 3, Text: "b"})
+
+	assert.Equal(t, lexAll(NewFromString("{ b c }")), got)
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package gogqllexer
+
+import "iter"
+
+// Tokens returns an iterator over the remaining tokens, excluding EOF.
+// Iteration stops after an Invalid token unless error recovery is enabled;
+// Err reports the reason of the last yielded Invalid token.
+func (l *Lexer) Tokens() iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		for {
+			t := l.NextToken()
+			if t.Kind == EOF {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+			if t.Kind == Invalid && !l.recoverErrors {
+				return
+			}
+		}
+	}
+}
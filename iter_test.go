@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package gogqllexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_Tokens(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		src  string
+		want []Kind
+	}{
+		{
+			name: "stops before EOF",
+			src:  "{ a }",
+			want: []Kind{BraceL, Name, BraceR},
+		},
+		{
+			name: "stops after invalid token",
+			src:  "a ? b",
+			want: []Kind{Name, Invalid},
+		},
+		{
+			name: "continues with error recovery",
+			opts: []Option{WithErrorRecovery()},
+			src:  "a ? b",
+			want: []Kind{Name, Invalid, Name},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]Kind, 0)
+			for tok := range NewFromString(tt.src, tt.opts...).Tokens() {
+				got = append(got, tok.Kind)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLexer_Tokens_Break(t *testing.T) {
+	l := NewFromString("a b c")
+	for tok := range l.Tokens() {
+		assert.Equal(t, "a", tok.Value)
+		break
+	}
+
+	assert.Equal(t, "b", l.NextToken().Value)
+}
@@ -0,0 +1,140 @@
+package gogqllexer
+
+import "fmt"
+
+type kindClass int
+
+const (
+	classOther kindClass = iota
+	classPunctuator
+	classLiteral
+	className
+	classComment
+)
+
+type kindInfo struct {
+	name  string
+	text  string
+	class kindClass
+}
+
+var kinds = [...]kindInfo{
+	Invalid:     {name: "Invalid"},
+	EOF:         {name: "EOF"},
+	Name:        {name: "Name", class: className},
+	Bang:        {name: "Bang", text: "!", class: classPunctuator},
+	Dollar:      {name: "Dollar", text: "$", class: classPunctuator},
+	Amp:         {name: "Amp", text: "&", class: classPunctuator},
+	ParenL:      {name: "ParenL", text: "(", class: classPunctuator},
+	ParenR:      {name: "ParenR", text: ")", class: classPunctuator},
+	Spread:      {name: "Spread", text: "...", class: classPunctuator},
+	Equal:       {name: "Equal", text: "=", class: classPunctuator},
+	At:          {name: "At", text: "@", class: classPunctuator},
+	Colon:       {name: "Colon", text: ":", class: classPunctuator},
+	BracketL:    {name: "BracketL", text: "[", class: classPunctuator},
+	BracketR:    {name: "BracketR", text: "]", class: classPunctuator},
+	BraceL:      {name: "BraceL", text: "{", class: classPunctuator},
+	BraceR:      {name: "BraceR", text: "}", class: classPunctuator},
+	Pipe:        {name: "Pipe", text: "|", class: classPunctuator},
+	Int:         {name: "Int", class: classLiteral},
+	Float:       {name: "Float", class: classLiteral},
+	String:      {name: "String", class: classLiteral},
+	BlockString: {name: "BlockString", class: classLiteral},
+	Comment:     {name: "Comment", class: classComment},
+}
+
+func (k Kind) info() (kindInfo, bool) {
+	if k < 0 || int(k) >= len(kinds) {
+		return kindInfo{}, false
+	}
+	return kinds[k], true
+}
+
+func (k Kind) String() string {
+	if info, ok := k.info(); ok {
+		return info.name
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Text returns the source text of a punctuator kind, or "" for other kinds.
+func (k Kind) Text() string {
+	info, _ := k.info()
+	return info.text
+}
+
+// https://spec.graphql.org/October2021/#Punctuator
+func (k Kind) IsPunctuator() bool {
+	info, _ := k.info()
+	return info.class == classPunctuator
+}
+
+// IsLiteral reports whether k is an Int, Float, String or BlockString.
+func (k Kind) IsLiteral() bool {
+	info, _ := k.info()
+	return info.class == classLiteral
+}
+
+// IsValue reports whether tokens of kind k carry their source text in Token.Value.
+func (k Kind) IsValue() bool {
+	info, _ := k.info()
+	return info.class == classLiteral || info.class == className || info.class == classComment
+}
+
+func (k Kind) MarshalText() ([]byte, error) {
+	info, ok := k.info()
+	if !ok {
+		return nil, fmt.Errorf("unknown token kind %d", int(k))
+	}
+	return []byte(info.name), nil
+}
+
+func (k *Kind) UnmarshalText(text []byte) error {
+	for i, info := range kinds {
+		if info.name == string(text) {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown token kind %q", text)
+}
+
+// Keyword is the part of the syntax that a keyword Name introduces.
+// GraphQL has no reserved words: whether a Name is used as a keyword depends on where it appears.
+type Keyword int
+
+const (
+	// OperationKeyword starts an operation or names its type: query, mutation and subscription.
+	OperationKeyword Keyword = iota + 1
+	// FragmentKeyword starts a fragment definition.
+	FragmentKeyword
+	// TypeSystemKeyword starts a type system definition or extension.
+	TypeSystemKeyword
+	// ModifierKeyword continues a definition: a type condition, implemented interfaces or a repeatable directive.
+	ModifierKeyword
+	// ValueKeyword is a boolean or null value.
+	ValueKeyword
+)
+
+// Keywords maps every GraphQL keyword to its Keyword.
+var Keywords = map[string]Keyword{
+	"query":        OperationKeyword,
+	"mutation":     OperationKeyword,
+	"subscription": OperationKeyword,
+	"fragment":     FragmentKeyword,
+	"schema":       TypeSystemKeyword,
+	"scalar":       TypeSystemKeyword,
+	"type":         TypeSystemKeyword,
+	"interface":    TypeSystemKeyword,
+	"union":        TypeSystemKeyword,
+	"enum":         TypeSystemKeyword,
+	"input":        TypeSystemKeyword,
+	"directive":    TypeSystemKeyword,
+	"extend":       TypeSystemKeyword,
+	"on":           ModifierKeyword,
+	"implements":   ModifierKeyword,
+	"repeatable":   ModifierKeyword,
+	"true":         ValueKeyword,
+	"false":        ValueKeyword,
+	"null":         ValueKeyword,
+}
@@ -0,0 +1,76 @@
+package gogqllexer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "Int", Int.String())
+	assert.Equal(t, "BraceL", BraceL.String())
+	assert.Equal(t, "Kind(100)", Kind(100).String())
+}
+
+func TestKind_Classification(t *testing.T) {
+	tests := []struct {
+		kind           Kind
+		wantText       string
+		wantPunctuator bool
+		wantLiteral    bool
+		wantValue      bool
+	}{
+		{kind: Invalid},
+		{kind: EOF},
+		{kind: Name, wantValue: true},
+		{kind: Spread, wantText: "...", wantPunctuator: true},
+		{kind: Pipe, wantText: "|", wantPunctuator: true},
+		{kind: Int, wantLiteral: true, wantValue: true},
+		{kind: BlockString, wantLiteral: true, wantValue: true},
+		{kind: Comment, wantValue: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			assert.Equal(t, tt.wantText, tt.kind.Text())
+			assert.Equal(t, tt.wantPunctuator, tt.kind.IsPunctuator())
+			assert.Equal(t, tt.wantLiteral, tt.kind.IsLiteral())
+			assert.Equal(t, tt.wantValue, tt.kind.IsValue())
+		})
+	}
+}
+
+func TestKind_MarshalText(t *testing.T) {
+	for k := range kinds {
+		kind := Kind(k)
+
+		b, err := json.Marshal(kind)
+		assert.NoError(t, err)
+
+		var got Kind
+		assert.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, kind, got)
+	}
+
+	b, err := json.Marshal(Token{Kind: Colon})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"Kind":"Colon"`)
+
+	var k Kind
+	assert.Error(t, k.UnmarshalText([]byte("Unknown")))
+	_, err = Kind(-1).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestKeywords(t *testing.T) {
+	for name, k := range Keywords {
+		tokens, err := All(name)
+		assert.NoError(t, err)
+		if assert.Len(t, tokens, 1, name) {
+			assert.Equal(t, Name, tokens[0].Kind, name)
+		}
+		assert.NotZero(t, k, name)
+	}
+	assert.Equal(t, TypeSystemKeyword, Keywords["extend"])
+	assert.NotContains(t, Keywords, "Query")
+}
@@ -1,7 +1,12 @@
 package gogqllexer
 
 import (
+	"fmt"
 	"io"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 type Lexer struct {
@@ -9,14 +14,65 @@ type Lexer struct {
 
 	line           int
 	startByteIndex int
+
+	cur  cursor
+	prev cursor
+	// synced is the offset up to which the lines and columns of cur have been counted.
+	// Lexers created by NewFromString only advance the offset of cur as they read runes,
+	// syncCursor counts the lines and columns once per token.
+	synced int
+
+	// src is the whole input when the lexer was created by NewFromString or NewFromBytes.
+	// Runes are then decoded from src instead of being read from the RuneScanner,
+	// and token values are substrings of src, otherwise they are copied from lexeme.
+	src        string
+	fromSource bool
+	// end is the offset up to which runes are decoded from src without checking the limits, see updateEnd.
+	// It is 0 for lexers reading a RuneScanner, so that the fast paths reading src are skipped.
+	end           int
+	lexeme        []byte
+	lexemeStart   int
+	prevLexemeLen int
+	// lexemeCursor and lexemeScanned are the cursor and the number of tokens scanned at the start of the lexeme.
+	lexemeCursor  cursor
+	lexemeScanned int
+	scanned       int
+
+	spec          Spec
+	emitComments  bool
+	recoverErrors bool
+	unicodeNames  bool
+
+	maxBytes      int
+	maxTokenBytes int
+	maxTokens     int
+	// inToken reports whether a token other than EOF is being read, for maxTokenBytes.
+	inToken bool
+	// limit is the message of the limit exceeded. Once it has been reported the lexer only returns EOF.
+	limit         string
+	limitReported bool
+
+	buffer tokenBuffer
+
+	// source is indexed as the lexer reads it when the lexer was created by Source.Lexer.
+	source *Source
+
+	err *LexError
 }
 
-func New(scanner io.RuneScanner) *Lexer {
-	return &Lexer{
+func New(scanner io.RuneScanner, opts ...Option) *Lexer {
+	l := &Lexer{
 		RuneScanner:    scanner,
 		line:           1,
 		startByteIndex: 0,
+		cur:            newCursor(),
+		lexemeCursor:   newCursor(),
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
+
+	return l
 }
 
 func (l *Lexer) makeEOFToken() Token {
@@ -41,40 +97,144 @@ func (l *Lexer) makeToken(kind Kind, value string) Token {
 	}
 }
 
-func (l *Lexer) NextToken() Token {
+// Err returns the reason the most recent token returned by NextToken is Invalid.
+// It returns nil when that token is valid.
+func (l *Lexer) Err() error {
+	if l.err == nil {
+		return nil
+	}
+	return l.err
+}
+
+func (l *Lexer) makeInvalidToken(code ErrorCode, text string, message string) Token {
+	t := l.makeToken(Invalid, "")
+	l.err = &LexError{
+		Code:     code,
+		Message:  message,
+		Text:     text,
+		Position: t.Position,
+		Spec:     l.spec,
+	}
+	return t
+}
+
+// scan reads the next token from the underlying RuneScanner into st.
+// st is filled in place, as copying tokens is a large part of the cost of lexing.
+func (l *Lexer) scan(st *scannedToken) {
+	l.err = nil
+	t := &st.token
+	if l.limitReported {
+		l.syncCursor()
+		*t = l.makeEOFToken()
+		t.Span = Span{
+			Start: l.cur.Location,
+			End:   l.cur.Location,
+		}
+		st.err = nil
+		return
+	}
+
 	consumedByte, consumedLine := l.skipIgnoreTokens()
 	l.startByteIndex += consumedByte
 	l.line += consumedLine
 
+	l.syncCursor()
+	start := l.cur.Location
+	pos := Position{
+		Line:  l.line,
+		Start: l.startByteIndex + 1,
+	}
+	if l.limit == "" && l.maxTokens > 0 && l.scanned >= l.maxTokens {
+		if _, err := l.peek(); err == nil {
+			l.limit = fmt.Sprintf("document exceeds the limit of %d tokens", l.maxTokens)
+		}
+	}
+	if l.limit == "" {
+		l.readToken(t)
+		if l.maxTokenBytes > 0 && l.inToken && l.cur.Offset-l.lexemeStart > l.maxTokenBytes {
+			l.limit = fmt.Sprintf("token exceeds the limit of %d bytes", l.maxTokenBytes)
+		}
+		l.inToken = false
+		l.updateEnd()
+	}
+	l.syncCursor()
+	if l.limit != "" {
+		// limits are never recovered from, the rest of the input is not read.
+		// Text is left empty so that an oversized token is not copied.
+		*t = Token{
+			Kind:     Invalid,
+			Position: pos,
+		}
+		l.err = &LexError{
+			Code:     ErrLimitExceeded,
+			Message:  l.limit,
+			Position: pos,
+		}
+		l.limitReported = true
+	} else if l.err != nil {
+		if l.recoverErrors {
+			l.recoverFromError()
+		}
+		// the bytes and lines counted for an error may fall short of what was read, the next Position starts at the cursor
+		l.startByteIndex = l.cur.Offset
+		l.line = l.cur.Line
+	}
+	t.Span.Start = start
+	t.Span.End = l.cur.Location
+	if l.err != nil {
+		l.err.Span = t.Span
+	}
+	if t.Kind != EOF {
+		l.scanned++
+	}
+	st.err = l.err
+}
+
+// readToken reads the token at the cursor into t.
+func (l *Lexer) readToken(t *Token) {
 	r, err := l.peek()
 	if err != nil {
-		return l.makeEOFToken()
+		// the lexeme keeps the ignored tokens read after the last token, for State
+		*t = l.makeEOFToken()
+		return
 	}
+	l.lexemeStart = l.cur.Offset
+	l.lexeme = l.lexeme[:0]
+	l.lexemeCursor = l.cur
+	l.lexemeScanned = l.scanned
+	l.inToken = true
+	l.updateEnd()
+	var consumedByte, consumedLine int
 	switch {
-	case isNameStart(r):
-		t, consumedByte := l.readNameToken()
-		l.startByteIndex += consumedByte
-		return t
+	case l.isNameStart(r):
+		*t, consumedByte = l.readNameToken()
 	case isPunctuator(r):
-		t, consumedByte := l.readPunctuatorToken()
-		l.startByteIndex += consumedByte
-		return t
+		*t, consumedByte = l.readPunctuatorToken()
 	case isNumber(r):
-		t, consumedByte := l.readNumber()
-		l.startByteIndex += consumedByte
-		return t
+		*t, consumedByte = l.readNumber()
 	case isStringValue(r):
-		t, consumedByte, consumedLine := l.readStringToken()
-		l.startByteIndex += consumedByte
-		l.line += consumedLine
-		return t
+		*t, consumedByte, consumedLine = l.readStringToken()
+	case isComment(r) && l.emitComments:
+		*t, consumedByte = l.readCommentToken()
 	default:
+		// the offending character is consumed, so that the next token starts after it
+		*t = l.makeInvalidToken(ErrUnexpectedCharacter, string(r), fmt.Sprintf("unexpected character %q", r))
+		_, consumedByte, _ = l.readRune()
 	}
-
-	return l.makeToken(Invalid, "")
+	l.startByteIndex += consumedByte
+	l.line += consumedLine
 }
 
 func (l *Lexer) peek() (rune, error) {
+	// an ASCII rune before end needs neither decoding nor limit checks, end is 0 for lexers reading a RuneScanner
+	if o := l.cur.Offset; o < l.end && l.src[o] < utf8.RuneSelf {
+		return rune(l.src[o]), nil
+	}
+	if l.fromSource {
+		r, _, err := l.decodeRune()
+		return r, err
+	}
+
 	r, _, err := l.ReadRune()
 	if err != nil {
 		return 0, err
@@ -84,6 +244,144 @@ func (l *Lexer) peek() (rune, error) {
 	return r, nil
 }
 
+// decodeRune decodes the rune at the cursor from src without advancing the cursor.
+// Like strings.Reader, it returns utf8.RuneError of size 1 for invalid UTF-8.
+func (l *Lexer) decodeRune() (rune, int, error) {
+	if l.cur.Offset < l.end {
+		r, size := rune(l.src[l.cur.Offset]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRuneInString(l.src[l.cur.Offset:])
+		}
+		if l.cur.Offset+size <= l.end {
+			return r, size, nil
+		}
+	}
+
+	// past end, the rune is read only if it is within src and the limits
+	if l.cur.Offset >= len(l.src) {
+		return 0, 0, io.EOF
+	}
+	r, size := utf8.DecodeRuneInString(l.src[l.cur.Offset:])
+	if (l.maxBytes > 0 || l.maxTokenBytes > 0) && l.exceedsLimit(size) {
+		return 0, 0, errLimitReached
+	}
+
+	return r, size, nil
+}
+
+// updateEnd sets end to the end of src, or to the first limit before it.
+// It is called whenever a limit starts or stops applying, so that decodeRune checks the limits only near them.
+func (l *Lexer) updateEnd() {
+	l.end = len(l.src)
+	if l.maxBytes > 0 && l.maxBytes < l.end {
+		l.end = l.maxBytes
+	}
+	if l.maxTokenBytes > 0 && l.inToken && l.lexemeStart+l.maxTokenBytes < l.end {
+		l.end = l.lexemeStart + l.maxTokenBytes
+	}
+}
+
+// readRune reads a rune and advances the cursor used for token spans.
+// For lexers created by NewFromString only the offset is advanced, see syncCursor.
+func (l *Lexer) readRune() (rune, int, error) {
+	if o := l.cur.Offset; o < l.end && l.src[o] < utf8.RuneSelf {
+		l.prev.Offset = o
+		l.cur.Offset = o + 1
+		return rune(l.src[o]), 1, nil
+	}
+	if l.fromSource {
+		r, s, err := l.decodeRune()
+		if err != nil {
+			return r, s, err
+		}
+		l.prev.Offset = l.cur.Offset
+		l.cur.Offset += s
+		return r, s, nil
+	}
+
+	r, s, err := l.ReadRune()
+	if err != nil {
+		return r, s, err
+	}
+	l.prev = l.cur
+	l.cur.advance(r, s)
+	l.prevLexemeLen = len(l.lexeme)
+	l.lexeme = utf8.AppendRune(l.lexeme, r)
+
+	return r, s, nil
+}
+
+func (l *Lexer) unreadRune() error {
+	if l.fromSource {
+		l.cur.Offset = l.prev.Offset
+		return nil
+	}
+
+	if err := l.UnreadRune(); err != nil {
+		return err
+	}
+	l.cur = l.prev
+	l.lexeme = l.lexeme[:l.prevLexemeLen]
+
+	return nil
+}
+
+// syncCursor counts the lines and columns of src up to the offset of the cursor,
+// and indexes them in the Source the lexer was created by.
+// It does nothing for lexers reading a RuneScanner, which advance the whole cursor for every rune.
+func (l *Lexer) syncCursor() {
+	if !l.fromSource {
+		return
+	}
+
+	c := &l.cur
+	line, column, utf16Column, last := c.Line, c.Column, c.UTF16Column, c.last
+	for i := l.synced; i < c.Offset; {
+		if b := l.src[i]; b < utf8.RuneSelf && b != '\n' && b != '\r' {
+			i++
+			column++
+			utf16Column++
+			last = rune(b)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(l.src[i:])
+		i += size
+		switch {
+		case r == '\n' && last == '\r':
+			// the line has already been advanced by '\r'
+		case isLineTerminator(r):
+			line++
+			column = 1
+			utf16Column = 1
+		default:
+			column += size
+			utf16Column += utf16Len(r)
+		}
+		last = r
+	}
+	c.Line, c.Column, c.UTF16Column, c.last = line, column, utf16Column, last
+	l.synced = c.Offset
+	if l.source != nil {
+		l.source.indexTo(c.Offset - 1)
+	}
+}
+
+// afterCR reports whether the rune before the last one read is '\r'.
+func (l *Lexer) afterCR() bool {
+	if l.fromSource {
+		return l.prev.Offset > 0 && l.src[l.prev.Offset-1] == '\r'
+	}
+	return l.prev.last == '\r'
+}
+
+// lexemeText returns the source text read since the start of the current token.
+func (l *Lexer) lexemeText() string {
+	if l.fromSource {
+		return l.src[l.lexemeStart:l.cur.Offset]
+	}
+	return string(l.lexeme)
+}
+
 func isNumber(r rune) bool {
 	return r == '-' || isDigit(r)
 }
@@ -111,7 +409,6 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 	isFloat := false
 	// TODO: fix name. スコープがでかいのに不鮮明な名前
 	s := 0
-	value := make([]rune, 0)
 
 	// check EOF
 	r, err := l.peek()
@@ -121,13 +418,12 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 
 	// check sign
 	if r == '-' {
-		r, s, _ = l.ReadRune()
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 
 		r, err = l.peek()
 		if err != nil {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), `expected digit after "-"`), consumedByte
 		}
 	}
 
@@ -137,28 +433,26 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 	// check reading 0
 	if r == '0' {
 		leadingZero = true
-		r, s, _ = l.ReadRune()
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 	}
 
 	for {
 		r, err = l.peek()
 		if err != nil {
-			return l.makeToken(Int, string(value)), consumedByte
+			return l.makeToken(Int, l.lexemeText()), consumedByte
 		}
 
 		if isDigit(r) {
 			if leadingZero {
-				return l.makeToken(Invalid, ""), consumedByte
+				return l.makeInvalidToken(ErrLeadingZero, l.lexemeText()+string(r), "unexpected digit after 0"), consumedByte
 			}
-			r, s, _ = l.ReadRune()
+			r, s, _ = l.readRune()
 			consumedByte += s
-			value = append(value, r)
-		} else if isNameStart(r) && !isExponentPart(r) {
-			_, s, _ = l.ReadRune()
+		} else if l.isNameStart(r) && !isExponentPart(r) {
+			_, s, _ = l.readRune()
 			consumedByte += s
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), fmt.Sprintf("unexpected character %q after number", r)), consumedByte
 		} else {
 			break
 		}
@@ -166,34 +460,32 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 
 	if isFractionalPart(r) {
 		isFloat = true
-		r, s, _ = l.ReadRune()
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 
-		// dot must be followed by at least one digit
-		r, s, err = l.ReadRune()
+		// dot must be followed by at least one digit, any other rune is left for the next token
+		r, err = l.peek()
 		if err != nil {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), `expected digit after "."`), consumedByte
 		}
 		if !isDigit(r) {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), fmt.Sprintf(`expected digit after ".", found %q`, r)), consumedByte
 		}
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 
 		for {
 			r, err = l.peek()
 			if err != nil {
-				return l.makeToken(Float, string(value)), consumedByte
+				return l.makeToken(Float, l.lexemeText()), consumedByte
 			}
 
 			if isDigit(r) {
-				r, s, _ = l.ReadRune()
+				r, s, _ = l.readRune()
 				consumedByte += s
-				value = append(value, r)
 				continue
-			} else if (isNameStart(r) && !isExponentPart(r)) || r == '.' {
-				return l.makeToken(Invalid, ""), consumedByte
+			} else if (l.isNameStart(r) && !isExponentPart(r)) || r == '.' {
+				return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText()+string(r), fmt.Sprintf("unexpected character %q after number", r)), consumedByte
 			} else {
 				break
 			}
@@ -202,46 +494,43 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 
 	if isExponentPart(r) {
 		isFloat = true
-		r, s, _ = l.ReadRune()
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 
 		// check opt sign
 		r, err = l.peek()
 		if err != nil {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), "expected digit in exponent"), consumedByte
 		}
 		if r == '-' || r == '+' {
-			r, s, _ = l.ReadRune()
+			r, s, _ = l.readRune()
 			consumedByte += s
-			value = append(value, r)
 		}
 
 		// must be followed by at least one digit
-		r, s, err = l.ReadRune()
+		r, err = l.peek()
 		if err != nil {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), "expected digit in exponent"), consumedByte
 		}
 		if !isDigit(r) {
-			return l.makeToken(Invalid, ""), consumedByte
+			return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText(), fmt.Sprintf("expected digit in exponent, found %q", r)), consumedByte
 		}
+		r, s, _ = l.readRune()
 		consumedByte += s
-		value = append(value, r)
 
 		for {
 			r, err = l.peek()
 			if err != nil {
-				return l.makeToken(Float, string(value)), consumedByte
+				return l.makeToken(Float, l.lexemeText()), consumedByte
 			}
 
 			if isDigit(r) {
-				r, s, _ = l.ReadRune()
+				r, s, _ = l.readRune()
 				consumedByte += s
-				value = append(value, r)
 
 				continue
-			} else if isNameStart(r) || r == '.' {
-				return l.makeToken(Invalid, ""), consumedByte
+			} else if l.isNameStart(r) || r == '.' {
+				return l.makeInvalidToken(ErrInvalidNumber, l.lexemeText()+string(r), fmt.Sprintf("unexpected character %q after number", r)), consumedByte
 			} else {
 				break
 			}
@@ -249,9 +538,9 @@ func (l *Lexer) readNumber() (token Token, consumedByte int) {
 	}
 
 	if isFloat {
-		return l.makeToken(Float, string(value)), consumedByte
+		return l.makeToken(Float, l.lexemeText()), consumedByte
 	} else {
-		return l.makeToken(Int, string(value)), consumedByte
+		return l.makeToken(Int, l.lexemeText()), consumedByte
 	}
 }
 
@@ -266,53 +555,54 @@ func isPunctuator(r rune) bool {
 }
 
 func (l *Lexer) readPunctuatorToken() (token Token, consumedByte int) {
-	r, consumedByte, err := l.ReadRune()
+	r, consumedByte, err := l.readRune()
 	if err != nil {
 		return l.makeEOFToken(), consumedByte
 	}
 
+	var kind Kind
 	switch r {
 	case '!':
-		return l.makeToken(Bang, ""), consumedByte
+		kind = Bang
 	case '$':
-		return l.makeToken(Dollar, ""), consumedByte
+		kind = Dollar
 	case '&':
-		return l.makeToken(Amp, ""), consumedByte
+		kind = Amp
 	case '(':
-		return l.makeToken(ParenL, ""), consumedByte
+		kind = ParenL
 	case ')':
-		return l.makeToken(ParenR, ""), consumedByte
+		kind = ParenR
 	case '.':
 		for i := 0; i < 2; i++ {
-			r, s, err := l.ReadRune()
-			if err != nil {
-				return l.makeToken(Invalid, ""), consumedByte
+			// the rune after an incomplete spread is left for the next token
+			if r, err := l.peek(); err != nil || r != '.' {
+				return l.makeInvalidToken(ErrIncompleteSpread, strings.Repeat(".", i+1), `expected "..."`), consumedByte
 			}
+			_, s, _ := l.readRune()
 			consumedByte += s
-			if r != '.' {
-				return l.makeToken(Invalid, ""), consumedByte
-			}
 		}
-		return l.makeToken(Spread, ""), consumedByte
+		kind = Spread
 	case ':':
-		return l.makeToken(Colon, ""), consumedByte
+		kind = Colon
 	case '=':
-		return l.makeToken(Equal, ""), consumedByte
+		kind = Equal
 	case '@':
-		return l.makeToken(At, ""), consumedByte
+		kind = At
 	case '[':
-		return l.makeToken(BracketL, ""), consumedByte
+		kind = BracketL
 	case ']':
-		return l.makeToken(BracketR, ""), consumedByte
+		kind = BracketR
 	case '{':
-		return l.makeToken(BraceL, ""), consumedByte
+		kind = BraceL
 	case '}':
-		return l.makeToken(BraceR, ""), consumedByte
+		kind = BraceR
 	case '|':
-		return l.makeToken(Pipe, ""), consumedByte
+		kind = Pipe
 	default:
-		return l.makeToken(Invalid, ""), consumedByte
+		return l.makeInvalidToken(ErrUnexpectedCharacter, string(r), fmt.Sprintf("unexpected character %q", r)), consumedByte
 	}
+
+	return l.makeToken(kind, ""), consumedByte
 }
 
 // https://spec.graphql.org/October2021/#NameStart
@@ -331,40 +621,62 @@ func isNameContinue(r rune) bool {
 }
 
 func (l *Lexer) readNameToken() (token Token, consumedByte int) {
-	value := make([]rune, 0)
+	nonSpec := false
+	makeNameToken := func() Token {
+		t := l.makeToken(Name, l.lexemeText())
+		t.NonSpec = nonSpec
+		return t
+	}
+
+	// ASCII name characters before end are consumed without decoding them, end is 0 for lexers reading a RuneScanner
+	o := l.cur.Offset
+	for o < l.end && isNameContinue(rune(l.src[o])) {
+		o++
+	}
+	consumedByte += o - l.cur.Offset
+	l.cur.Offset = o
+
 	for {
-		r, s, err := l.ReadRune()
+		r, s, err := l.readRune()
 		if err != nil {
 			//EOF
-			return l.makeToken(Name, string(value)), consumedByte
+			return makeNameToken(), consumedByte
 		}
-		if isNameContinue(r) {
+		if l.isNameContinue(r) {
 			consumedByte += s
-			value = append(value, r)
+			nonSpec = nonSpec || !isNameContinue(r)
 			continue
 		}
-		_ = l.UnreadRune()
+		_ = l.unreadRune()
 
-		return l.makeToken(Name, string(value)), consumedByte
+		return makeNameToken(), consumedByte
 	}
 }
 
+// isNameStart also accepts Unicode letters when WithUnicodeNames is set.
+func (l *Lexer) isNameStart(r rune) bool {
+	return isNameStart(r) || l.unicodeNames && unicode.IsLetter(r)
+}
+
+// isNameContinue also accepts Unicode letters, digits and combining marks when WithUnicodeNames is set.
+func (l *Lexer) isNameContinue(r rune) bool {
+	return isNameContinue(r) || l.unicodeNames && unicode.In(r, unicode.L, unicode.Nd, unicode.Mn, unicode.Mc)
+}
+
 // https://spec.graphql.org/draft/#sec-String-Value
 func isStringValue(r rune) bool {
 	return r == '"'
 }
 
 func (l *Lexer) readStringToken() (token Token, consumedByte int, consumedLine int) {
-	value := make([]rune, 0)
-	r, s, err := l.ReadRune()
+	r, s, err := l.readRune()
 	if err != nil {
 		return l.makeEOFToken(), consumedByte, consumedLine
 	}
 	consumedByte += s
-	value = append(value, r)
 
 	if r != '"' {
-		return l.makeToken(Invalid, ""), consumedByte, consumedLine
+		return l.makeInvalidToken(ErrUnexpectedCharacter, l.lexemeText(), fmt.Sprintf("unexpected character %q", r)), consumedByte, consumedLine
 	}
 
 	isBlockString := false
@@ -393,72 +705,63 @@ func (l *Lexer) readStringToken() (token Token, consumedByte int, consumedLine i
 
 StringReadLoop:
 	for {
-		r, s, err = l.ReadRune()
+		r, s, err = l.readRune()
 		if err != nil {
-			return l.makeToken(Invalid, ""), consumedByte, consumedLine
+			return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated string"), consumedByte, consumedLine
 		}
 		consumedByte += s
-		value = append(value, r)
 
 		switch r {
 		case '\n', '\r':
-			return l.makeToken(Invalid, ""), consumedByte, consumedLine
+			// the line terminator has been consumed with the string
+			return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated string"), consumedByte, consumedLine + 1
 		case '"':
 			r, err = l.peek()
 			if err != nil {
-				return makeStringToken(string(value)), consumedByte, consumedLine
+				return makeStringToken(l.lexemeText()), consumedByte, consumedLine
 			}
-			if r == '"' {
+			// only an empty string followed by a quote opens a block string
+			if r == '"' && consumedByte == 2 {
 				isBlockString = true
-				r, s, _ = l.ReadRune()
+				r, s, _ = l.readRune()
 				consumedByte += s
-				value = append(value, r)
 				break StringReadLoop
 			} else {
-				return makeStringToken(string(value)), consumedByte, consumedLine
+				return makeStringToken(l.lexemeText()), consumedByte, consumedLine
 			}
 		case '\\':
-			r, s, err = l.ReadRune()
+			r, s, err = l.readRune()
 			if err != nil {
-				return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated string"), consumedByte, consumedLine
 			}
 			consumedByte += s
-			value = append(value, r)
 
 			switch r {
 			default:
-				return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				return l.makeInvalidToken(ErrInvalidEscapeSequence, l.lexemeText(), fmt.Sprintf(`invalid escape sequence "\%c"`, r)), consumedByte, consumedLine
 			case 'u':
-				for i := 0; i < 4; i++ {
-					r, s, err = l.ReadRune()
-					if err != nil {
-						return l.makeToken(Invalid, ""), consumedByte, consumedLine
-					}
-					consumedByte += s
-					value = append(value, r)
-
-					if !isHexDigit(r) {
-						return l.makeToken(Invalid, ""), consumedByte, consumedLine
-					}
+				s, code, message := l.readEscapedUnicode()
+				consumedByte += s
+				if code != 0 {
+					return l.makeInvalidToken(code, l.lexemeText(), message), consumedByte, consumedLine
 				}
 			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
 				break
 			}
 		default:
-			if r < 0x0020 && r != '\t' {
-				return l.makeToken(Invalid, ""), consumedByte, consumedLine
+			if !l.isStringChar(r, s) {
+				return l.makeInvalidToken(ErrInvalidCharacterInString, l.lexemeText(), fmt.Sprintf("invalid character %U within string", r)), consumedByte, consumedLine
 			}
 		}
 	}
 
 	if isBlockString {
 		for {
-			r, s, err = l.ReadRune()
+			r, s, err = l.readRune()
 			if err != nil {
-				return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated block string"), consumedByte, consumedLine
 			}
 			consumedByte += s
-			value = append(value, r)
 
 			switch r {
 			case '\n':
@@ -466,61 +769,139 @@ StringReadLoop:
 			case '\r':
 				consumedLine++
 				if r, err = l.peek(); err != nil {
-					return l.makeToken(Invalid, ""), consumedByte, consumedLine
+					return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated block string"), consumedByte, consumedLine
 				} else if r == '\n' {
-					r, s, _ = l.ReadRune()
+					r, s, _ = l.readRune()
 					consumedByte += s
-					value = append(value, r)
 				}
 			case '"':
-				for i := 0; i < 2; i++ {
-					r, s, err = l.ReadRune()
-					if err != nil {
-						return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				// a lone or doubled quote is part of the block string, only """ closes it
+				quotes := 1
+				for quotes < 3 {
+					if r, err = l.peek(); err != nil || r != '"' {
+						break
 					}
+					r, s, _ = l.readRune()
 					consumedByte += s
-					value = append(value, r)
-					if r != '"' {
-						return l.makeToken(Invalid, ""), consumedByte, consumedLine
-					}
+					quotes++
 				}
-				return makeBlockStringToken(string(value)), consumedByte, consumedLine
-			case '\\':
-				r, s, err = l.ReadRune()
-				if err != nil {
-					return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				if quotes == 3 {
+					return makeBlockStringToken(l.lexemeText()), consumedByte, consumedLine
 				}
-				consumedByte += s
-				value = append(value, r)
-
-				switch r {
-				default:
-					return l.makeToken(Invalid, ""), consumedByte, consumedLine
-				case 'u':
-					for i := 0; i < 4; i++ {
-						r, s, err = l.ReadRune()
-						if err != nil {
-							return l.makeToken(Invalid, ""), consumedByte, consumedLine
-						}
-						consumedByte += s
-						value = append(value, r)
-
-						if !isHexDigit(r) {
-							return l.makeToken(Invalid, ""), consumedByte, consumedLine
-						}
+			case '\\':
+				// https://spec.graphql.org/October2021/#BlockStringCharacter
+				// \""" is the only escape sequence in block strings
+				for i := 0; i < 3; i++ {
+					if r, err = l.peek(); err != nil || r != '"' {
+						break
 					}
-				case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
-					break
+					r, s, _ = l.readRune()
+					consumedByte += s
 				}
 			default:
-				if r < 0x0020 && r != '\t' && r != '\n' && r != '\r' {
-					return l.makeToken(Invalid, ""), consumedByte, consumedLine
+				if !l.isStringChar(r, s) {
+					return l.makeInvalidToken(ErrInvalidCharacterInString, l.lexemeText(), fmt.Sprintf("invalid character %U within block string", r)), consumedByte, consumedLine
 				}
 			}
 		}
 	}
 
-	return l.makeToken(Invalid, ""), consumedByte, consumedLine
+	return l.makeInvalidToken(ErrUnterminatedString, l.lexemeText(), "unterminated block string"), consumedByte, consumedLine
+}
+
+// readEscapedUnicode reads the rest of an escape sequence following "\u".
+// It returns a non-zero code when the sequence is invalid.
+// https://spec.graphql.org/October2021/#EscapedUnicode
+// https://spec.graphql.org/draft/#EscapedUnicode
+func (l *Lexer) readEscapedUnicode() (consumedByte int, code ErrorCode, message string) {
+	if r, err := l.peek(); err == nil && r == '{' && l.spec == Draft {
+		_, s, _ := l.readRune()
+		consumedByte += s
+
+		value, digits := rune(0), 0
+		for {
+			r, s, err := l.readRune()
+			if err != nil {
+				return consumedByte, ErrUnterminatedString, "unterminated string"
+			}
+			consumedByte += s
+			if r == '}' {
+				break
+			}
+			if !isHexDigit(r) {
+				return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence"
+			}
+			value = value<<4 | hexValue(r)
+			digits++
+			if value > utf8.MaxRune {
+				return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence, out of range"
+			}
+		}
+		if digits == 0 {
+			return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence"
+		}
+		if utf16.IsSurrogate(value) {
+			return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence, surrogate code point"
+		}
+		return consumedByte, 0, ""
+	}
+
+	value, s, code, message := l.readHexDigits4()
+	consumedByte += s
+	if code != 0 || l.spec != Draft || !utf16.IsSurrogate(value) {
+		return consumedByte, code, message
+	}
+
+	// https://spec.graphql.org/draft/#sec-String-Value.Static-Semantics
+	// a leading surrogate must be followed by an escaped trailing surrogate
+	if value >= 0xDC00 {
+		return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence, unpaired surrogate"
+	}
+	for _, want := range []rune{'\\', 'u'} {
+		if r, err := l.peek(); err != nil || r != want {
+			return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence, unpaired surrogate"
+		}
+		_, s, _ := l.readRune()
+		consumedByte += s
+	}
+	trailing, s, code, message := l.readHexDigits4()
+	consumedByte += s
+	if code != 0 {
+		return consumedByte, code, message
+	}
+	if trailing < 0xDC00 || 0xDFFF < trailing {
+		return consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence, unpaired surrogate"
+	}
+
+	return consumedByte, 0, ""
+}
+
+func (l *Lexer) readHexDigits4() (value rune, consumedByte int, code ErrorCode, message string) {
+	for i := 0; i < 4; i++ {
+		r, s, err := l.readRune()
+		if err != nil {
+			return 0, consumedByte, ErrUnterminatedString, "unterminated string"
+		}
+		consumedByte += s
+
+		if !isHexDigit(r) {
+			return 0, consumedByte, ErrInvalidEscapeSequence, "invalid unicode escape sequence"
+		}
+		value = value<<4 | hexValue(r)
+	}
+
+	return value, consumedByte, 0, ""
+}
+
+func hexValue(r rune) rune {
+	switch {
+	case isDigit(r):
+		return r - '0'
+	case 'a' <= r && r <= 'f':
+		return r - 'a' + 10
+	default:
+		return r - 'A' + 10
+	}
 }
 
 // https://spec.graphql.org/October2021/#sec-Line-Terminators
@@ -533,12 +914,40 @@ func isLineTerminator(r rune) bool {
 	}
 }
 
+// https://spec.graphql.org/October2021/#sec-Comments
+func isComment(r rune) bool {
+	return r == '#'
+}
+
+func (l *Lexer) readCommentToken() (token Token, consumedByte int) {
+	for {
+		r, err := l.peek()
+		if err != nil {
+			return l.makeToken(Comment, l.lexemeText()), consumedByte
+		}
+		if consumedByte > 0 && !l.isCommentChar(r) {
+			return l.makeToken(Comment, l.lexemeText()), consumedByte
+		}
+
+		_, s, _ := l.readRune()
+		consumedByte += s
+	}
+}
+
 // https://spec.graphql.org/October2021/#sec-Language.Source-Text.Ignored-Tokens
 // Commentを除く
 func (l *Lexer) skipIgnoreTokens() (consumedByte int, consumedLine int) {
 ReadIgnoredTokenLoop:
 	for {
-		r, s, err := l.ReadRune()
+		// spaces, tabs and commas before end are skipped without decoding them, end is 0 for lexers reading a RuneScanner
+		o := l.cur.Offset
+		for o < l.end && (l.src[o] == ' ' || l.src[o] == '\t' || l.src[o] == ',') {
+			o++
+		}
+		consumedByte += o - l.cur.Offset
+		l.cur.Offset = o
+
+		r, s, err := l.readRune()
 		if err != nil {
 			break ReadIgnoredTokenLoop
 		}
@@ -559,18 +968,29 @@ ReadIgnoredTokenLoop:
 		// line terminator
 		case isLineTerminator(r):
 			consumedByte += s
+			// the '\r' of this "\r\n" ended the previous token and has already been counted
+			if r == '\n' && l.afterCR() {
+				continue
+			}
 			consumedLine++
-			r, s, err = l.ReadRune()
+			if r != '\r' {
+				continue
+			}
+			r, s, err = l.readRune()
 			if err != nil {
 				break ReadIgnoredTokenLoop
 			}
 			if r == '\n' {
 				consumedByte += s
 			} else {
-				_ = l.UnreadRune()
+				_ = l.unreadRune()
 			}
 			continue
 		case r == '#':
+			if l.emitComments {
+				_ = l.unreadRune()
+				break ReadIgnoredTokenLoop
+			}
 			consumedByte += s
 			for {
 				r, err = l.peek()
@@ -578,15 +998,15 @@ ReadIgnoredTokenLoop:
 					break
 				}
 
-				if isLineTerminator(r) || r < 0x0020 && r != '\t' {
+				if !l.isCommentChar(r) {
 					break
 				}
 
-				_, s, _ = l.ReadRune()
+				_, s, _ = l.readRune()
 				consumedByte += s
 			}
 		default:
-			_ = l.UnreadRune()
+			_ = l.unreadRune()
 			break ReadIgnoredTokenLoop
 		}
 	}
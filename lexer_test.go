@@ -133,6 +133,8 @@ query # comment
 			gotTokens := make([]Token, 0)
 			for {
 				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
 
 				gotTokens = append(gotTokens, got)
 				if got.Kind == EOF {
@@ -189,6 +191,8 @@ func TestLexer_NextToken_Name(t *testing.T) {
 			gotTokens := make([]Token, 0)
 			for {
 				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
 
 				gotTokens = append(gotTokens, got)
 				if got.Kind == EOF || got.Kind == Invalid {
@@ -341,6 +345,8 @@ func TestLexer_NextToken_Punctuator(t *testing.T) {
 			gotTokens := make([]Token, 0)
 			for {
 				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
 
 				gotTokens = append(gotTokens, got)
 				if got.Kind == EOF || got.Kind == Invalid {
@@ -930,6 +936,8 @@ func TestLexer_NextToken_ReadNumber(t *testing.T) {
 			gotTokens := make([]Token, 0)
 			for {
 				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
 
 				gotTokens = append(gotTokens, got)
 				if got.Kind == EOF || got.Kind == Invalid {
@@ -1366,6 +1374,35 @@ func TestLexer_NextToken_String(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "adjacent strings",
+			src:  "\"a\"\"b\"",
+			want: []Token{
+				{
+					Kind:  String,
+					Value: "\"a\"",
+					Position: Position{
+						Line:  1,
+						Start: 1,
+					},
+				},
+				{
+					Kind:  String,
+					Value: "\"b\"",
+					Position: Position{
+						Line:  1,
+						Start: 4,
+					},
+				},
+				{
+					Kind: EOF,
+					Position: Position{
+						Line:  1,
+						Start: 6,
+					},
+				},
+			},
+		},
 		// block string
 		{
 			name: "empty block string",
@@ -1484,6 +1521,8 @@ func TestLexer_NextToken_String(t *testing.T) {
 			gotTokens := make([]Token, 0)
 			for {
 				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
 
 				gotTokens = append(gotTokens, got)
 				if got.Kind == EOF || got.Kind == Invalid {
@@ -1495,3 +1534,865 @@ func TestLexer_NextToken_String(t *testing.T) {
 		})
 	}
 }
+
+func TestLexer_Err(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want *LexError
+	}{
+		{
+			name: "valid token",
+			src:  "query",
+			want: nil,
+		},
+		{
+			name: "unexpected character",
+			src:  "  ?",
+			want: &LexError{
+				Code:    ErrUnexpectedCharacter,
+				Message: `unexpected character '?'`,
+				Text:    "?",
+				Position: Position{
+					Line:  1,
+					Start: 3,
+				},
+			},
+		},
+		{
+			name: "leading zero",
+			src:  "0123",
+			want: &LexError{
+				Code:    ErrLeadingZero,
+				Message: "unexpected digit after 0",
+				Text:    "01",
+				Position: Position{
+					Line:  1,
+					Start: 1,
+				},
+			},
+		},
+		{
+			name: "missing fractional digit",
+			src:  "1.a",
+			want: &LexError{
+				Code:    ErrInvalidNumber,
+				Message: `expected digit after ".", found 'a'`,
+				Text:    "1.",
+				Position: Position{
+					Line:  1,
+					Start: 1,
+				},
+			},
+		},
+		{
+			name: "incomplete spread",
+			src:  "..a",
+			want: &LexError{
+				Code:    ErrIncompleteSpread,
+				Message: `expected "..."`,
+				Text:    "..",
+				Position: Position{
+					Line:  1,
+					Start: 1,
+				},
+			},
+		},
+		{
+			name: "unterminated string",
+			src:  "\n \n \n \"abc",
+			want: &LexError{
+				Code:    ErrUnterminatedString,
+				Message: "unterminated string",
+				Text:    "\"abc",
+				Position: Position{
+					Line:  4,
+					Start: 7,
+				},
+			},
+		},
+		{
+			name: "invalid escape sequence",
+			src:  "\"\\x\"",
+			want: &LexError{
+				Code:    ErrInvalidEscapeSequence,
+				Message: `invalid escape sequence "\x"`,
+				Text:    "\"\\x",
+				Position: Position{
+					Line:  1,
+					Start: 1,
+				},
+			},
+		},
+		{
+			name: "invalid character in string",
+			src:  "\"\u0001\"",
+			want: &LexError{
+				Code:    ErrInvalidCharacterInString,
+				Message: "invalid character U+0001 within string",
+				Text:    "\"\u0001",
+				Position: Position{
+					Line:  1,
+					Start: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src))
+			l.NextToken()
+
+			if tt.want == nil {
+				assert.NoError(t, l.Err())
+				return
+			}
+			var got *LexError
+			assert.ErrorAs(t, l.Err(), &got)
+			got.Span = Span{}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLexError_Error(t *testing.T) {
+	l := New(strings.NewReader("{\n  a(x: 01)\n}"))
+	for tok := l.NextToken(); tok.Kind != Invalid; tok = l.NextToken() {
+	}
+
+	// line and column of the token, not Position.Start, which counts from the start of the document
+	assert.EqualError(t, l.Err(), "2:8: unexpected digit after 0")
+}
+
+func TestLexer_NextToken_Span(t *testing.T) {
+	loc := func(offset, line, column, utf16Column int) Location {
+		return Location{
+			Offset:      offset,
+			Line:        line,
+			Column:      column,
+			UTF16Column: utf16Column,
+		}
+	}
+
+	tests := []struct {
+		name string
+		src  string
+		want []Span
+	}{
+		{
+			name: "single line",
+			src:  "query { a }",
+			want: []Span{
+				{Start: loc(0, 1, 1, 1), End: loc(5, 1, 6, 6)},
+				{Start: loc(6, 1, 7, 7), End: loc(7, 1, 8, 8)},
+				{Start: loc(8, 1, 9, 9), End: loc(9, 1, 10, 10)},
+				{Start: loc(10, 1, 11, 11), End: loc(11, 1, 12, 12)},
+				{Start: loc(11, 1, 12, 12), End: loc(11, 1, 12, 12)},
+			},
+		},
+		{
+			name: "line terminators",
+			src:  "a\r\nb\n\nc\rd",
+			want: []Span{
+				{Start: loc(0, 1, 1, 1), End: loc(1, 1, 2, 2)},
+				{Start: loc(3, 2, 1, 1), End: loc(4, 2, 2, 2)},
+				{Start: loc(6, 4, 1, 1), End: loc(7, 4, 2, 2)},
+				{Start: loc(8, 5, 1, 1), End: loc(9, 5, 2, 2)},
+				{Start: loc(9, 5, 2, 2), End: loc(9, 5, 2, 2)},
+			},
+		},
+		{
+			name: "unicode byte order mark",
+			src:  "\uFEFFquery",
+			want: []Span{
+				{Start: loc(3, 1, 4, 2), End: loc(8, 1, 9, 7)},
+				{Start: loc(8, 1, 9, 7), End: loc(8, 1, 9, 7)},
+			},
+		},
+		{
+			name: "string outside the basic multilingual plane",
+			src:  "\"😀\" a",
+			want: []Span{
+				{Start: loc(0, 1, 1, 1), End: loc(6, 1, 7, 5)},
+				{Start: loc(7, 1, 8, 6), End: loc(8, 1, 9, 7)},
+				{Start: loc(8, 1, 9, 7), End: loc(8, 1, 9, 7)},
+			},
+		},
+		{
+			name: "block string spanning lines",
+			src:  "\"\"\"a\r\nbc\"\"\" d",
+			want: []Span{
+				{Start: loc(0, 1, 1, 1), End: loc(11, 2, 6, 6)},
+				{Start: loc(12, 2, 7, 7), End: loc(13, 2, 8, 8)},
+				{Start: loc(13, 2, 8, 8), End: loc(13, 2, 8, 8)},
+			},
+		},
+		{
+			name: "invalid token",
+			src:  " 1.a",
+			want: []Span{
+				{Start: loc(1, 1, 2, 2), End: loc(3, 1, 4, 4)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src))
+
+			gotSpans := make([]Span, 0)
+			for {
+				got := l.NextToken()
+
+				gotSpans = append(gotSpans, got.Span)
+				if got.Kind == EOF || got.Kind == Invalid {
+					if got.Kind == Invalid {
+						var err *LexError
+						assert.ErrorAs(t, l.Err(), &err)
+						assert.Equal(t, got.Span, err.Span)
+					}
+					break
+				}
+			}
+
+			assert.Equal(t, tt.want, gotSpans)
+		})
+	}
+}
+
+func TestLexer_NextToken_LineAfterUnterminatedString(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts []Option
+	}{
+		{
+			name: "line feed",
+			src:  "x \"a\nb",
+		},
+		{
+			name: "carriage return",
+			src:  "x \"a\rb",
+		},
+		{
+			name: "carriage return line feed",
+			src:  "x \"a\r\n\r\nb",
+		},
+		{
+			name: "carriage return line feed with error recovery",
+			src:  "x \"a\r\n\r\nb",
+			opts: []Option{WithErrorRecovery()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src), tt.opts...)
+			for {
+				got := l.NextToken()
+
+				// the line terminator ending the string is counted once
+				assert.Equal(t, got.Span.Start.Line, got.Position.Line, got.Kind.String())
+				if got.Kind == EOF {
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLexer_NextToken_AfterUnexpectedCharacter(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		want      []Token
+		wantSpans [][2]int
+	}{
+		{
+			name: "ascii",
+			src:  "a ? b",
+			want: []Token{
+				{Kind: Name, Value: "a", Position: Position{Line: 1, Start: 1}},
+				{Kind: Invalid, Position: Position{Line: 1, Start: 3}},
+				{Kind: Name, Value: "b", Position: Position{Line: 1, Start: 5}},
+				{Kind: EOF, Position: Position{Line: 1, Start: 5}},
+			},
+			wantSpans: [][2]int{{0, 1}, {2, 3}, {4, 5}, {5, 5}},
+		},
+		{
+			name: "outside the basic multilingual plane",
+			src:  "a \U0001F600b",
+			want: []Token{
+				{Kind: Name, Value: "a", Position: Position{Line: 1, Start: 1}},
+				{Kind: Invalid, Position: Position{Line: 1, Start: 3}},
+				{Kind: Name, Value: "b", Position: Position{Line: 1, Start: 7}},
+				{Kind: EOF, Position: Position{Line: 1, Start: 7}},
+			},
+			wantSpans: [][2]int{{0, 1}, {2, 6}, {6, 7}, {7, 7}},
+		},
+		{
+			name: "invalid number before a line terminator",
+			src:  "1.\n a",
+			want: []Token{
+				{Kind: Invalid, Position: Position{Line: 1, Start: 1}},
+				{Kind: Name, Value: "a", Position: Position{Line: 2, Start: 5}},
+				{Kind: EOF, Position: Position{Line: 2, Start: 5}},
+			},
+			wantSpans: [][2]int{{0, 2}, {4, 5}, {5, 5}},
+		},
+		{
+			name: "unterminated string",
+			src:  "\"a\r\nb",
+			want: []Token{
+				{Kind: Invalid, Position: Position{Line: 1, Start: 1}},
+				{Kind: Name, Value: "b", Position: Position{Line: 2, Start: 5}},
+				{Kind: EOF, Position: Position{Line: 2, Start: 5}},
+			},
+			wantSpans: [][2]int{{0, 3}, {4, 5}, {5, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// without error recovery the lexer still moves past the offending character
+			l := New(strings.NewReader(tt.src))
+
+			gotTokens := make([]Token, 0)
+			gotSpans := make([][2]int, 0)
+			for i := 0; i <= len(tt.src); i++ {
+				got := l.NextToken()
+				gotSpans = append(gotSpans, [2]int{got.Span.Start.Offset, got.Span.End.Offset})
+				got.Span = Span{}
+
+				gotTokens = append(gotTokens, got)
+				if got.Kind == EOF {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.want, gotTokens)
+			assert.Equal(t, tt.wantSpans, gotSpans)
+		})
+	}
+}
+
+func TestLexer_NextToken_Comment(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Token
+	}{
+		{
+			name: "comments",
+			src:  "# first\r\nquery #second\n#",
+			want: []Token{
+				{
+					Kind:  Comment,
+					Value: "# first",
+					Position: Position{
+						Line:  1,
+						Start: 1,
+					},
+				},
+				{
+					Kind:  Name,
+					Value: "query",
+					Position: Position{
+						Line:  2,
+						Start: 10,
+					},
+				},
+				{
+					Kind:  Comment,
+					Value: "#second",
+					Position: Position{
+						Line:  2,
+						Start: 16,
+					},
+				},
+				{
+					Kind:  Comment,
+					Value: "#",
+					Position: Position{
+						Line:  3,
+						Start: 24,
+					},
+				},
+				{
+					Kind:  EOF,
+					Value: "",
+					Position: Position{
+						Line:  3,
+						Start: 24,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src), WithComments())
+
+			gotTokens := make([]Token, 0)
+			for {
+				got := l.NextToken()
+				// spans are covered by TestLexer_NextToken_Span
+				got.Span = Span{}
+
+				gotTokens = append(gotTokens, got)
+				if got.Kind == EOF || got.Kind == Invalid {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.want, gotTokens)
+		})
+	}
+}
+
+func TestLexer_NextToken_ErrorRecovery(t *testing.T) {
+	type skipped struct {
+		start int
+		end   int
+	}
+
+	tests := []struct {
+		name        string
+		src         string
+		want        []Token
+		wantSkipped []skipped
+	}{
+		{
+			name: "unexpected character",
+			src:  "a ? b",
+			want: []Token{
+				{Kind: Name, Value: "a"},
+				{Kind: Invalid},
+				{Kind: Name, Value: "b"},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 3, end: 3}},
+		},
+		{
+			name: "number resynchronizes at next non-name character",
+			src:  "0123abc.5 x 1.2.3{",
+			want: []Token{
+				{Kind: Invalid},
+				{Kind: Name, Value: "x"},
+				{Kind: Invalid},
+				{Kind: BraceL},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 1, end: 9}, {start: 15, end: 17}},
+		},
+		{
+			name: "incomplete spread keeps the next token",
+			src:  "{ a .} ..b",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "a"},
+				{Kind: Invalid},
+				{Kind: BraceR},
+				{Kind: Invalid},
+				{Kind: Name, Value: "b"},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 5, end: 5}, {start: 9, end: 9}},
+		},
+		{
+			name: "missing fractional digit keeps the next token",
+			src:  "{ b(x: 1.) }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "b"},
+				{Kind: ParenL},
+				{Kind: Name, Value: "x"},
+				{Kind: Colon},
+				{Kind: Invalid},
+				{Kind: ParenR},
+				{Kind: BraceR},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 9, end: 9}},
+		},
+		{
+			name: "missing exponent digit keeps the next token",
+			src:  "{ c(x: 1e) }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "c"},
+				{Kind: ParenL},
+				{Kind: Name, Value: "x"},
+				{Kind: Colon},
+				{Kind: Invalid},
+				{Kind: ParenR},
+				{Kind: BraceR},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 9, end: 9}},
+		},
+		{
+			name: "invalid escape resynchronizes after closing quote",
+			src:  "\"a\\x \\\" b\" c",
+			want: []Token{
+				{Kind: Invalid},
+				{Kind: Name, Value: "c"},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 4, end: 10}},
+		},
+		{
+			name: "unterminated string resynchronizes at end of line",
+			src:  "\"abc\nd\n\"e\rf",
+			want: []Token{
+				{Kind: Invalid},
+				{Kind: Name, Value: "d"},
+				{Kind: Invalid},
+				{Kind: Name, Value: "f"},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 5, end: 5}, {start: 10, end: 10}},
+		},
+		{
+			name: "invalid character in block string",
+			src:  "\"\"\"a\u0001 \\\"\"\" \"\"\" b",
+			want: []Token{
+				{Kind: Invalid},
+				{Kind: Name, Value: "b"},
+				{Kind: EOF},
+			},
+			wantSkipped: []skipped{{start: 5, end: 14}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src), WithErrorRecovery())
+
+			gotTokens := make([]Token, 0)
+			gotSkipped := make([]skipped, 0)
+			for {
+				got := l.NextToken()
+				if got.Kind == Invalid {
+					var err *LexError
+					assert.ErrorAs(t, l.Err(), &err)
+					assert.Equal(t, got.Span, err.Span)
+					assert.Equal(t, err.Skipped.End, got.Span.End)
+					gotSkipped = append(gotSkipped, skipped{start: err.Skipped.Start.Offset, end: err.Skipped.End.Offset})
+				}
+
+				gotTokens = append(gotTokens, Token{Kind: got.Kind, Value: got.Value})
+				if got.Kind == EOF {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.want, gotTokens)
+			assert.Equal(t, tt.wantSkipped, gotSkipped)
+		})
+	}
+}
+
+func TestLexer_NextToken_Spec(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		wantOct   ErrorCode
+		wantDraft ErrorCode
+	}{
+		{
+			name:      "variable-width unicode escape",
+			src:       `"\u{1F600}"`,
+			wantOct:   ErrInvalidEscapeSequence,
+			wantDraft: 0,
+		},
+		{
+			name:      "variable-width unicode escape out of range",
+			src:       `"\u{110000}"`,
+			wantOct:   ErrInvalidEscapeSequence,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "variable-width unicode escape of surrogate",
+			src:       `"\u{D83D}"`,
+			wantOct:   ErrInvalidEscapeSequence,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "empty variable-width unicode escape",
+			src:       `"\u{}"`,
+			wantOct:   ErrInvalidEscapeSequence,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "surrogate pair",
+			src:       `"\uD83D\uDE00"`,
+			wantOct:   0,
+			wantDraft: 0,
+		},
+		{
+			name:      "unpaired leading surrogate",
+			src:       `"\uD83D x"`,
+			wantOct:   0,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "leading surrogate followed by non surrogate",
+			src:       `"\uD83DA"`,
+			wantOct:   0,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "unpaired trailing surrogate",
+			src:       `"\uDE00"`,
+			wantOct:   0,
+			wantDraft: ErrInvalidEscapeSequence,
+		},
+		{
+			name:      "invalid utf-8 in string",
+			src:       "\"\xff\"",
+			wantOct:   0,
+			wantDraft: ErrInvalidCharacterInString,
+		},
+		{
+			name:      "invalid utf-8 in block string",
+			src:       "\"\"\"\xff\"\"\"",
+			wantOct:   0,
+			wantDraft: ErrInvalidCharacterInString,
+		},
+		{
+			name:      "control character in string",
+			src:       "\"a\u0001b\"",
+			wantOct:   ErrInvalidCharacterInString,
+			wantDraft: 0,
+		},
+		{
+			name:      "null character in string",
+			src:       "\"\u0000\"",
+			wantOct:   ErrInvalidCharacterInString,
+			wantDraft: 0,
+		},
+		{
+			name:      "control character in block string",
+			src:       "\"\"\"a\u0007\nb\"\"\"",
+			wantOct:   ErrInvalidCharacterInString,
+			wantDraft: 0,
+		},
+		{
+			name:      "line feed in string",
+			src:       "\"a\nb\"",
+			wantOct:   ErrUnterminatedString,
+			wantDraft: ErrUnterminatedString,
+		},
+		{
+			name:      "control character in comment",
+			src:       "# comment \u0001 a\nb",
+			wantOct:   ErrUnexpectedCharacter,
+			wantDraft: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for spec, want := range map[Spec]ErrorCode{October2021: tt.wantOct, Draft: tt.wantDraft} {
+				l := New(strings.NewReader(tt.src), WithSpec(spec))
+				l.NextToken()
+
+				var got ErrorCode
+				if err, ok := l.Err().(*LexError); ok {
+					got = err.Code
+				}
+				assert.Equal(t, want, got, "spec %d", spec)
+			}
+		})
+	}
+}
+
+func TestLexer_NextToken_UnicodeNames(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		src  string
+		want []Token
+	}{
+		{
+			name: "unicode names are invalid by default",
+			src:  "type Café",
+			want: []Token{
+				{Kind: Name, Value: "type"},
+				{Kind: Name, Value: "Caf"},
+				{Kind: Invalid},
+			},
+		},
+		{
+			name: "unicode names",
+			opts: []Option{WithUnicodeNames()},
+			src:  "type Café {名前: String _x1 }",
+			want: []Token{
+				{Kind: Name, Value: "type"},
+				{Kind: Name, Value: "Café", NonSpec: true},
+				{Kind: BraceL},
+				{Kind: Name, Value: "名前", NonSpec: true},
+				{Kind: Colon},
+				{Kind: Name, Value: "String"},
+				{Kind: Name, Value: "_x1"},
+				{Kind: BraceR},
+				{Kind: EOF},
+			},
+		},
+		{
+			name: "number followed by unicode letter",
+			opts: []Option{WithUnicodeNames()},
+			src:  "1é",
+			want: []Token{
+				{Kind: Invalid},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(strings.NewReader(tt.src), tt.opts...)
+
+			gotTokens := make([]Token, 0)
+			for {
+				got := l.NextToken()
+
+				gotTokens = append(gotTokens, Token{Kind: got.Kind, Value: got.Value, NonSpec: got.NonSpec})
+				if got.Kind == EOF || got.Kind == Invalid {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.want, gotTokens)
+		})
+	}
+}
+
+func TestLexer_NextToken_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		src     string
+		want    []Token
+		wantErr string
+	}{
+		{
+			name: "input of max bytes",
+			opts: []Option{WithMaxBytes(7)},
+			src:  "{ a b }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "a"},
+				{Kind: Name, Value: "b"},
+				{Kind: BraceR},
+				{Kind: EOF},
+			},
+		},
+		{
+			name: "input exceeds max bytes",
+			opts: []Option{WithMaxBytes(6)},
+			src:  "{ a b }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "a"},
+				{Kind: Name, Value: "b"},
+				{Kind: Invalid},
+				{Kind: EOF},
+			},
+			wantErr: "input exceeds the limit of 6 bytes",
+		},
+		{
+			name: "input exceeds max bytes in the middle of a token",
+			opts: []Option{WithMaxBytes(4), WithErrorRecovery()},
+			src:  "{ abc }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Invalid},
+				{Kind: EOF},
+			},
+			wantErr: "input exceeds the limit of 4 bytes",
+		},
+		{
+			name: "token of max token bytes",
+			opts: []Option{WithMaxTokenBytes(3)},
+			src:  "abc\"x\" abc",
+			want: []Token{
+				{Kind: Name, Value: "abc"},
+				{Kind: String, Value: "\"x\""},
+				{Kind: Name, Value: "abc"},
+				{Kind: EOF},
+			},
+		},
+		{
+			name: "name exceeds max token bytes",
+			opts: []Option{WithMaxTokenBytes(3)},
+			src:  "abc abcd",
+			want: []Token{
+				{Kind: Name, Value: "abc"},
+				{Kind: Invalid},
+				{Kind: EOF},
+			},
+			wantErr: "token exceeds the limit of 3 bytes",
+		},
+		{
+			name: "block string exceeds max token bytes",
+			opts: []Option{WithMaxTokenBytes(8), WithErrorRecovery()},
+			src:  "\"\"\"" + strings.Repeat("a", 100) + "\"\"\"",
+			want: []Token{
+				{Kind: Invalid},
+				{Kind: EOF},
+			},
+			wantErr: "token exceeds the limit of 8 bytes",
+		},
+		{
+			name: "max tokens",
+			opts: []Option{WithMaxTokens(3)},
+			src:  "{ a } ",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "a"},
+				{Kind: BraceR},
+				{Kind: EOF},
+			},
+		},
+		{
+			name: "document exceeds max tokens",
+			opts: []Option{WithMaxTokens(2), WithErrorRecovery()},
+			src:  "{ a }",
+			want: []Token{
+				{Kind: BraceL},
+				{Kind: Name, Value: "a"},
+				{Kind: Invalid},
+				{Kind: EOF},
+			},
+			wantErr: "document exceeds the limit of 2 tokens",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// NewFromString checks the limits as it decodes runes, New through the RuneScanner
+			for _, l := range []*Lexer{New(strings.NewReader(tt.src), tt.opts...), NewFromString(tt.src, tt.opts...)} {
+				gotTokens := make([]Token, 0)
+				var gotErr error
+				for {
+					got := l.NextToken()
+
+					gotTokens = append(gotTokens, Token{Kind: got.Kind, Value: got.Value})
+					if got.Kind == Invalid {
+						gotErr = l.Err()
+					}
+					if got.Kind == EOF {
+						break
+					}
+				}
+
+				assert.Equal(t, tt.want, gotTokens)
+				if tt.wantErr == "" {
+					assert.NoError(t, gotErr)
+					continue
+				}
+				var lexErr *LexError
+				if assert.ErrorAs(t, gotErr, &lexErr) {
+					assert.Equal(t, ErrLimitExceeded, lexErr.Code)
+					assert.Equal(t, tt.wantErr, lexErr.Message)
+				}
+				assert.ErrorIs(t, gotErr, ErrLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package gogqllexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrLimit matches, with errors.Is, the LexError of a limit set by WithMaxBytes, WithMaxTokenBytes or WithMaxTokens.
+var ErrLimit = errors.New("gogqllexer: limit exceeded")
+
+// errLimitReached ends the input seen by the lexer when a limit would be exceeded.
+var errLimitReached = errors.New("gogqllexer: limit reached")
+
+// limitScanner refuses to read a rune past the limits of l.
+// Peeks go through it too, so a rune that cannot be read cannot be peeked either.
+// Lexers created by NewFromString check the limits as they decode runes instead.
+type limitScanner struct {
+	io.RuneScanner
+	l *Lexer
+}
+
+func (l *Lexer) limitInput() {
+	if _, ok := l.RuneScanner.(*limitScanner); !ok {
+		l.RuneScanner = &limitScanner{
+			RuneScanner: l.RuneScanner,
+			l:           l,
+		}
+	}
+}
+
+func (s *limitScanner) ReadRune() (rune, int, error) {
+	r, size, err := s.RuneScanner.ReadRune()
+	if err != nil {
+		return r, size, err
+	}
+
+	if !s.l.exceedsLimit(size) {
+		return r, size, nil
+	}
+	_ = s.RuneScanner.UnreadRune()
+
+	return 0, 0, errLimitReached
+}
+
+// exceedsLimit reports whether reading a rune of size bytes at the cursor exceeds a byte limit,
+// and records the limit in l.limit if so.
+func (l *Lexer) exceedsLimit(size int) bool {
+	switch {
+	case l.maxBytes > 0 && l.cur.Offset+size > l.maxBytes:
+		l.limit = fmt.Sprintf("input exceeds the limit of %d bytes", l.maxBytes)
+	case l.maxTokenBytes > 0 && l.inToken && l.cur.Offset-l.lexemeStart > l.maxTokenBytes:
+		// a token of exactly maxTokenBytes may still peek at the rune after it
+		l.limit = fmt.Sprintf("token exceeds the limit of %d bytes", l.maxTokenBytes)
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+package gogqllexer
+
+type Option func(l *Lexer)
+
+// WithComments makes the lexer return comments as Comment tokens instead of skipping them.
+func WithComments() Option {
+	return func(l *Lexer) {
+		l.emitComments = true
+	}
+}
+
+// WithErrorRecovery makes the lexer skip the rest of a broken lexeme after an Invalid token,
+// so that the next call to NextToken starts at a sensible boundary.
+// The skipped range is reported in LexError.Skipped.
+func WithErrorRecovery() Option {
+	return func(l *Lexer) {
+		l.recoverErrors = true
+	}
+}
+
+// WithUnicodeNames makes the lexer accept Unicode letters in names, as some legacy schemas contain them.
+// Such names do not conform to the spec and their tokens have NonSpec set.
+// https://spec.graphql.org/October2021/#Name
+func WithUnicodeNames() Option {
+	return func(l *Lexer) {
+		l.unicodeNames = true
+	}
+}
+
+// WithMaxBytes limits the input to n bytes. Lexing stops with an ErrLimitExceeded error
+// when more input follows, without reading it.
+func WithMaxBytes(n int) Option {
+	return func(l *Lexer) {
+		l.maxBytes = n
+		l.limitInput()
+	}
+}
+
+// WithMaxTokenBytes limits every token to n bytes, so that an oversized string or name is rejected
+// after reading at most n bytes of it plus one rune.
+func WithMaxTokenBytes(n int) Option {
+	return func(l *Lexer) {
+		l.maxTokenBytes = n
+		l.limitInput()
+	}
+}
+
+// WithMaxTokens limits the number of tokens other than EOF to n.
+func WithMaxTokens(n int) Option {
+	return func(l *Lexer) {
+		l.maxTokens = n
+	}
+}
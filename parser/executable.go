@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+)
+
+// ParseExecutable parses an executable document.
+// https://spec.graphql.org/October2021/#ExecutableDocument
+func ParseExecutable(lexer *gogqllexer.Lexer) (*ast.Document, error) {
+	p := newParser(lexer)
+	doc := &ast.Document{
+		Position: p.tok.Position,
+	}
+
+	for p.err == nil && !p.peek(gogqllexer.EOF) {
+		doc.Definitions = append(doc.Definitions, p.parseExecutableDefinition())
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if len(doc.Definitions) == 0 {
+		p.unexpected()
+		return nil, p.err
+	}
+
+	return doc, nil
+}
+
+// https://spec.graphql.org/October2021/#ExecutableDefinition
+func (p *parser) parseExecutableDefinition() ast.Definition {
+	switch {
+	case p.peek(gogqllexer.BraceL):
+		return p.parseOperationDefinition()
+	case p.peekKeyword("query"), p.peekKeyword("mutation"), p.peekKeyword("subscription"):
+		return p.parseOperationDefinition()
+	case p.peekKeyword("fragment"):
+		return p.parseFragmentDefinition()
+	default:
+		p.unexpected()
+		return nil
+	}
+}
+
+// https://spec.graphql.org/October2021/#OperationDefinition
+func (p *parser) parseOperationDefinition() *ast.OperationDefinition {
+	op := &ast.OperationDefinition{
+		Position: p.tok.Position,
+	}
+
+	if p.peek(gogqllexer.BraceL) {
+		op.Operation = ast.Query
+		op.SelectionSet = p.parseSelectionSet()
+		return op
+	}
+
+	op.Operation = p.parseOperationType()
+	if p.peek(gogqllexer.Name) {
+		op.Name = p.parseName()
+	}
+	op.VariableDefinitions = p.parseVariableDefinitions()
+	op.Directives = p.parseDirectives(false)
+	op.SelectionSet = p.parseSelectionSet()
+
+	return op
+}
+
+// https://spec.graphql.org/October2021/#OperationType
+func (p *parser) parseOperationType() ast.OperationType {
+	t := p.expect(gogqllexer.Name)
+	switch t.Value {
+	case "query":
+		return ast.Query
+	case "mutation":
+		return ast.Mutation
+	case "subscription":
+		return ast.Subscription
+	default:
+		p.errorf("unexpected Name %q, expected operation type", t.Value)
+		return ""
+	}
+}
+
+// https://spec.graphql.org/October2021/#VariableDefinitions
+func (p *parser) parseVariableDefinitions() []*ast.VariableDefinition {
+	if !p.peek(gogqllexer.ParenL) {
+		return nil
+	}
+
+	var defs []*ast.VariableDefinition
+	p.many(gogqllexer.ParenL, gogqllexer.ParenR, func() {
+		def := &ast.VariableDefinition{
+			Position: p.tok.Position,
+		}
+		def.Variable = p.parseVariable()
+		p.expect(gogqllexer.Colon)
+		def.Type = p.parseType()
+		if p.skip(gogqllexer.Equal) {
+			def.DefaultValue = p.parseValue(true)
+		}
+		def.Directives = p.parseDirectives(true)
+		defs = append(defs, def)
+	})
+
+	return defs
+}
+
+// https://spec.graphql.org/October2021/#Variable
+func (p *parser) parseVariable() *ast.Variable {
+	t := p.expect(gogqllexer.Dollar)
+
+	return &ast.Variable{
+		Name:     p.parseName(),
+		Position: t.Position,
+	}
+}
+
+// https://spec.graphql.org/October2021/#SelectionSet
+func (p *parser) parseSelectionSet() *ast.SelectionSet {
+	set := &ast.SelectionSet{
+		Position: p.tok.Position,
+	}
+	p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+		set.Selections = append(set.Selections, p.parseSelection())
+	})
+
+	return set
+}
+
+// https://spec.graphql.org/October2021/#Selection
+func (p *parser) parseSelection() ast.Selection {
+	if p.peek(gogqllexer.Spread) {
+		return p.parseFragment()
+	}
+
+	return p.parseField()
+}
+
+// https://spec.graphql.org/October2021/#Field
+func (p *parser) parseField() *ast.Field {
+	f := &ast.Field{
+		Position: p.tok.Position,
+	}
+
+	f.Name = p.parseName()
+	if p.skip(gogqllexer.Colon) {
+		f.Alias = f.Name
+		f.Name = p.parseName()
+	}
+	f.Arguments = p.parseArguments(false)
+	f.Directives = p.parseDirectives(false)
+	if p.peek(gogqllexer.BraceL) {
+		f.SelectionSet = p.parseSelectionSet()
+	}
+
+	return f
+}
+
+// https://spec.graphql.org/October2021/#Arguments
+func (p *parser) parseArguments(isConst bool) []*ast.Argument {
+	if !p.peek(gogqllexer.ParenL) {
+		return nil
+	}
+
+	var args []*ast.Argument
+	p.many(gogqllexer.ParenL, gogqllexer.ParenR, func() {
+		arg := &ast.Argument{
+			Position: p.tok.Position,
+		}
+		arg.Name = p.parseName()
+		p.expect(gogqllexer.Colon)
+		arg.Value = p.parseValue(isConst)
+		args = append(args, arg)
+	})
+
+	return args
+}
+
+// https://spec.graphql.org/October2021/#FragmentSpread
+// https://spec.graphql.org/October2021/#InlineFragment
+func (p *parser) parseFragment() ast.Selection {
+	start := p.expect(gogqllexer.Spread)
+
+	if p.peek(gogqllexer.Name) && !p.peekKeyword("on") {
+		return &ast.FragmentSpread{
+			Name:       p.parseName(),
+			Directives: p.parseDirectives(false),
+			Position:   start.Position,
+		}
+	}
+
+	f := &ast.InlineFragment{
+		Position: start.Position,
+	}
+	if p.peekKeyword("on") {
+		f.TypeCondition = p.parseTypeCondition()
+	}
+	f.Directives = p.parseDirectives(false)
+	f.SelectionSet = p.parseSelectionSet()
+
+	return f
+}
+
+// https://spec.graphql.org/October2021/#FragmentDefinition
+func (p *parser) parseFragmentDefinition() *ast.FragmentDefinition {
+	def := &ast.FragmentDefinition{
+		Position: p.tok.Position,
+	}
+	p.expectKeyword("fragment")
+
+	if p.peekKeyword("on") {
+		p.errorf(`unexpected Name "on", expected fragment name`)
+		return def
+	}
+	def.Name = p.parseName()
+	def.TypeCondition = p.parseTypeCondition()
+	def.Directives = p.parseDirectives(false)
+	def.SelectionSet = p.parseSelectionSet()
+
+	return def
+}
+
+// https://spec.graphql.org/October2021/#TypeCondition
+func (p *parser) parseTypeCondition() *ast.NamedType {
+	p.expectKeyword("on")
+
+	return p.parseNamedType()
+}
@@ -0,0 +1,371 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func pos(line, start int) gogqllexer.Position {
+	return gogqllexer.Position{
+		Line:  line,
+		Start: start,
+	}
+}
+
+func TestParseExecutable(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want *ast.Document
+	}{
+		{
+			name: "shorthand query",
+			src:  "{ a }",
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.OperationDefinition{
+						Operation: ast.Query,
+						Position:  pos(1, 1),
+						SelectionSet: &ast.SelectionSet{
+							Position: pos(1, 1),
+							Selections: []ast.Selection{
+								&ast.Field{
+									Name:     &ast.Name{Value: "a", Position: pos(1, 3)},
+									Position: pos(1, 3),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "operation with variables and directives",
+			src:  `query Q($id: ID! = 1 @d, $l: [Int]) @skip(if: true) { alias: f(id: $id) }`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.OperationDefinition{
+						Operation: ast.Query,
+						Name:      &ast.Name{Value: "Q", Position: pos(1, 7)},
+						VariableDefinitions: []*ast.VariableDefinition{
+							{
+								Variable: &ast.Variable{
+									Name:     &ast.Name{Value: "id", Position: pos(1, 10)},
+									Position: pos(1, 9),
+								},
+								Type: &ast.NonNullType{
+									Type: &ast.NamedType{
+										Name:     &ast.Name{Value: "ID", Position: pos(1, 14)},
+										Position: pos(1, 14),
+									},
+									Position: pos(1, 14),
+								},
+								DefaultValue: &ast.IntValue{Value: "1", Position: pos(1, 20)},
+								Directives: []*ast.Directive{
+									{
+										Name:     &ast.Name{Value: "d", Position: pos(1, 23)},
+										Position: pos(1, 22),
+									},
+								},
+								Position: pos(1, 9),
+							},
+							{
+								Variable: &ast.Variable{
+									Name:     &ast.Name{Value: "l", Position: pos(1, 27)},
+									Position: pos(1, 26),
+								},
+								Type: &ast.ListType{
+									Type: &ast.NamedType{
+										Name:     &ast.Name{Value: "Int", Position: pos(1, 31)},
+										Position: pos(1, 31),
+									},
+									Position: pos(1, 30),
+								},
+								Position: pos(1, 26),
+							},
+						},
+						Directives: []*ast.Directive{
+							{
+								Name: &ast.Name{Value: "skip", Position: pos(1, 38)},
+								Arguments: []*ast.Argument{
+									{
+										Name:     &ast.Name{Value: "if", Position: pos(1, 43)},
+										Value:    &ast.BooleanValue{Value: true, Position: pos(1, 47)},
+										Position: pos(1, 43),
+									},
+								},
+								Position: pos(1, 37),
+							},
+						},
+						SelectionSet: &ast.SelectionSet{
+							Position: pos(1, 53),
+							Selections: []ast.Selection{
+								&ast.Field{
+									Alias: &ast.Name{Value: "alias", Position: pos(1, 55)},
+									Name:  &ast.Name{Value: "f", Position: pos(1, 62)},
+									Arguments: []*ast.Argument{
+										{
+											Name: &ast.Name{Value: "id", Position: pos(1, 64)},
+											Value: &ast.Variable{
+												Name:     &ast.Name{Value: "id", Position: pos(1, 69)},
+												Position: pos(1, 68),
+											},
+											Position: pos(1, 64),
+										},
+									},
+									Position: pos(1, 55),
+								},
+							},
+						},
+						Position: pos(1, 1),
+					},
+				},
+			},
+		},
+		{
+			name: "fragments",
+			src: `{ ...F ... on T { a } ... @d { b } }
+fragment F on T { c }`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.OperationDefinition{
+						Operation: ast.Query,
+						Position:  pos(1, 1),
+						SelectionSet: &ast.SelectionSet{
+							Position: pos(1, 1),
+							Selections: []ast.Selection{
+								&ast.FragmentSpread{
+									Name:     &ast.Name{Value: "F", Position: pos(1, 6)},
+									Position: pos(1, 3),
+								},
+								&ast.InlineFragment{
+									TypeCondition: &ast.NamedType{
+										Name:     &ast.Name{Value: "T", Position: pos(1, 15)},
+										Position: pos(1, 15),
+									},
+									SelectionSet: &ast.SelectionSet{
+										Position: pos(1, 17),
+										Selections: []ast.Selection{
+											&ast.Field{
+												Name:     &ast.Name{Value: "a", Position: pos(1, 19)},
+												Position: pos(1, 19),
+											},
+										},
+									},
+									Position: pos(1, 8),
+								},
+								&ast.InlineFragment{
+									Directives: []*ast.Directive{
+										{
+											Name:     &ast.Name{Value: "d", Position: pos(1, 28)},
+											Position: pos(1, 27),
+										},
+									},
+									SelectionSet: &ast.SelectionSet{
+										Position: pos(1, 30),
+										Selections: []ast.Selection{
+											&ast.Field{
+												Name:     &ast.Name{Value: "b", Position: pos(1, 32)},
+												Position: pos(1, 32),
+											},
+										},
+									},
+									Position: pos(1, 23),
+								},
+							},
+						},
+					},
+					&ast.FragmentDefinition{
+						Name: &ast.Name{Value: "F", Position: pos(2, 47)},
+						TypeCondition: &ast.NamedType{
+							Name:     &ast.Name{Value: "T", Position: pos(2, 52)},
+							Position: pos(2, 52),
+						},
+						SelectionSet: &ast.SelectionSet{
+							Position: pos(2, 54),
+							Selections: []ast.Selection{
+								&ast.Field{
+									Name:     &ast.Name{Value: "c", Position: pos(2, 56)},
+									Position: pos(2, 56),
+								},
+							},
+						},
+						Position: pos(2, 38),
+					},
+				},
+			},
+		},
+		{
+			name: "values",
+			src:  `{ f(a: [1, 2.5, "s", """b""", null, E, {x: false}]) }`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.OperationDefinition{
+						Operation: ast.Query,
+						Position:  pos(1, 1),
+						SelectionSet: &ast.SelectionSet{
+							Position: pos(1, 1),
+							Selections: []ast.Selection{
+								&ast.Field{
+									Name: &ast.Name{Value: "f", Position: pos(1, 3)},
+									Arguments: []*ast.Argument{
+										{
+											Name: &ast.Name{Value: "a", Position: pos(1, 5)},
+											Value: &ast.ListValue{
+												Values: []ast.Value{
+													&ast.IntValue{Value: "1", Position: pos(1, 9)},
+													&ast.FloatValue{Value: "2.5", Position: pos(1, 12)},
+													&ast.StringValue{Value: "s", Raw: `"s"`, Position: pos(1, 17)},
+													&ast.StringValue{Value: "b", Raw: `"""b"""`, Block: true, Position: pos(1, 22)},
+													&ast.NullValue{Position: pos(1, 31)},
+													&ast.EnumValue{Value: "E", Position: pos(1, 37)},
+													&ast.ObjectValue{
+														Fields: []*ast.ObjectField{
+															{
+																Name:     &ast.Name{Value: "x", Position: pos(1, 41)},
+																Value:    &ast.BooleanValue{Value: false, Position: pos(1, 44)},
+																Position: pos(1, 41),
+															},
+														},
+														Position: pos(1, 40),
+													},
+												},
+												Position: pos(1, 8),
+											},
+											Position: pos(1, 5),
+										},
+									},
+									Position: pos(1, 3),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExecutable(gogqllexer.New(strings.NewReader(tt.src)))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExecutable_Comments(t *testing.T) {
+	src := `# leading
+{ a # trailing
+}`
+	got, err := ParseExecutable(gogqllexer.New(strings.NewReader(src), gogqllexer.WithComments()))
+
+	assert.NoError(t, err)
+	assert.Len(t, got.Definitions, 1)
+}
+
+func TestParseExecutable_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		wantMessage string
+		wantPos     gogqllexer.Position
+		wantError   string
+		wantErr     bool
+		wantLexErr  bool
+	}{
+		{
+			name:        "empty document",
+			src:         "",
+			wantMessage: "unexpected <EOF>",
+			wantPos:     pos(1, 0),
+			wantError:   `1:1: unexpected <EOF>`,
+		},
+		{
+			name:        "unclosed selection set",
+			src:         "{ a",
+			wantMessage: "expected Name, found <EOF>",
+			wantPos:     pos(1, 3),
+			wantError:   `1:4: expected Name, found <EOF>`,
+		},
+		{
+			name:        "variable in constant value",
+			src:         "query ($a: Int = $b) { a }",
+			wantMessage: "unexpected variable in constant value",
+			wantPos:     pos(1, 18),
+			wantError:   `1:18: unexpected variable in constant value`,
+		},
+		{
+			name:        "fragment named on",
+			src:         "fragment on on T { a }",
+			wantMessage: `unexpected Name "on", expected fragment name`,
+			wantPos:     pos(1, 10),
+			wantError:   `1:10: unexpected Name "on", expected fragment name`,
+		},
+		{
+			name:        "lexical error",
+			src:         "{ a(x: 01) }",
+			wantMessage: "unexpected digit after 0",
+			wantPos:     pos(1, 8),
+			wantError:   `1:8: unexpected digit after 0`,
+			wantErr:     true,
+			wantLexErr:  true,
+		},
+		{
+			name:        "lexical error on a later line",
+			src:         "{\n  a(x: 01)\n}",
+			wantMessage: "unexpected digit after 0",
+			wantPos:     pos(2, 10),
+			wantError:   "2:8: unexpected digit after 0",
+			wantErr:     true,
+			wantLexErr:  true,
+		},
+		{
+			name:        "unexpected character",
+			src:         "{ a ?",
+			wantMessage: "unexpected character '?'",
+			wantPos:     pos(1, 5),
+			wantError:   `1:5: unexpected character '?'`,
+			wantErr:     true,
+			wantLexErr:  true,
+		},
+		{
+			name:        "invalid string value",
+			src:         `{ a(x: "\uD800") }`,
+			wantMessage: `invalid unicode escape sequence "\\uD800", unpaired surrogate`,
+			wantPos:     pos(1, 8),
+			wantError:   `1:8: invalid unicode escape sequence "\\uD800", unpaired surrogate`,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExecutable(gogqllexer.New(strings.NewReader(tt.src)))
+
+			var got *Error
+			if assert.ErrorAs(t, err, &got) {
+				assert.Equal(t, tt.wantMessage, got.Message)
+				assert.Equal(t, tt.wantPos, got.Position)
+				assert.Equal(t, tt.wantError, got.Error())
+				assert.Equal(t, tt.wantErr, got.Err != nil)
+			}
+			var lexErr *gogqllexer.LexError
+			assert.Equal(t, tt.wantLexErr, errors.As(err, &lexErr))
+		})
+	}
+}
+
+func TestParseExecutable_Limit(t *testing.T) {
+	_, err := ParseExecutable(gogqllexer.New(strings.NewReader("{ a b c }"), gogqllexer.WithMaxTokens(3)))
+
+	assert.ErrorIs(t, err, gogqllexer.ErrLimit)
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+)
+
+type Error struct {
+	Message  string
+	Position gogqllexer.Position
+	// Span is the span of the token at which the error was detected.
+	Span gogqllexer.Span
+	// Err is the lexical error that caused the parse to fail, if any.
+	Err error
+}
+
+// Error returns the message prefixed with the line and byte column of the token at which the error was detected.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Span.Start.Line, e.Span.Start.Column, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type parser struct {
+	lexer *gogqllexer.Lexer
+
+	tok gogqllexer.Token
+	err error
+}
+
+func newParser(lexer *gogqllexer.Lexer) *parser {
+	p := &parser{
+		lexer: lexer,
+	}
+	p.next()
+
+	return p
+}
+
+func (p *parser) next() gogqllexer.Token {
+	prev := p.tok
+	if p.err != nil {
+		return prev
+	}
+
+	p.tok = p.lexer.NextToken()
+	for p.tok.Kind == gogqllexer.Comment {
+		p.tok = p.lexer.NextToken()
+	}
+	if p.tok.Kind == gogqllexer.Invalid {
+		err := p.lexer.Err()
+		// the message of a LexError is not prefixed with the position, which Error adds
+		message := err.Error()
+		var lexErr *gogqllexer.LexError
+		if errors.As(err, &lexErr) {
+			message = lexErr.Message
+		}
+		p.err = &Error{
+			Message:  message,
+			Position: p.tok.Position,
+			Span:     p.tok.Span,
+			Err:      err,
+		}
+	}
+
+	return prev
+}
+
+func (p *parser) errorf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	p.err = &Error{
+		Message:  fmt.Sprintf(format, args...),
+		Position: p.tok.Position,
+		Span:     p.tok.Span,
+	}
+}
+
+func (p *parser) unexpected() {
+	p.errorf("unexpected %s", describe(p.tok))
+}
+
+func (p *parser) peek(kind gogqllexer.Kind) bool {
+	return p.err == nil && p.tok.Kind == kind
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	return p.peek(gogqllexer.Name) && p.tok.Value == keyword
+}
+
+func (p *parser) skip(kind gogqllexer.Kind) bool {
+	if !p.peek(kind) {
+		return false
+	}
+	p.next()
+
+	return true
+}
+
+func (p *parser) expect(kind gogqllexer.Kind) gogqllexer.Token {
+	if !p.peek(kind) {
+		p.errorf("expected %s, found %s", describeKind(kind), describe(p.tok))
+		return p.tok
+	}
+
+	return p.next()
+}
+
+func (p *parser) expectKeyword(keyword string) gogqllexer.Token {
+	if !p.peekKeyword(keyword) {
+		p.errorf("expected %q, found %s", keyword, describe(p.tok))
+		return p.tok
+	}
+
+	return p.next()
+}
+
+// many parses one or more items enclosed by open and close.
+func (p *parser) many(open, close gogqllexer.Kind, fn func()) {
+	p.expect(open)
+	for p.err == nil {
+		fn()
+		if p.skip(close) {
+			return
+		}
+	}
+}
+
+// some parses zero or more items enclosed by open and close.
+func (p *parser) some(open, close gogqllexer.Kind, fn func()) {
+	p.expect(open)
+	for p.err == nil && !p.skip(close) {
+		fn()
+	}
+}
+
+func (p *parser) parseName() *ast.Name {
+	t := p.expect(gogqllexer.Name)
+
+	return &ast.Name{
+		Value:    t.Value,
+		Position: t.Position,
+	}
+}
+
+func describeKind(kind gogqllexer.Kind) string {
+	switch {
+	case kind == gogqllexer.EOF:
+		return "<EOF>"
+	case kind.IsPunctuator():
+		return strconv.Quote(kind.Text())
+	default:
+		return kind.String()
+	}
+}
+
+func describe(t gogqllexer.Token) string {
+	if t.Value == "" {
+		return describeKind(t.Kind)
+	}
+
+	return fmt.Sprintf("%s %q", describeKind(t.Kind), t.Value)
+}
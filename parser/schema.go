@@ -0,0 +1,530 @@
+package parser
+
+import (
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+)
+
+// ParseSchema parses a type system document, which may also contain type system extensions.
+// https://spec.graphql.org/October2021/#TypeSystemDocument
+// https://spec.graphql.org/October2021/#TypeSystemExtensionDocument
+func ParseSchema(lexer *gogqllexer.Lexer) (*ast.Document, error) {
+	p := newParser(lexer)
+	doc := &ast.Document{
+		Position: p.tok.Position,
+	}
+
+	for p.err == nil && !p.peek(gogqllexer.EOF) {
+		doc.Definitions = append(doc.Definitions, p.parseTypeSystemDefinitionOrExtension())
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if len(doc.Definitions) == 0 {
+		p.unexpected()
+		return nil, p.err
+	}
+
+	return doc, nil
+}
+
+// https://spec.graphql.org/October2021/#TypeSystemDefinitionOrExtension
+func (p *parser) parseTypeSystemDefinitionOrExtension() ast.Definition {
+	if p.peekKeyword("extend") {
+		return p.parseTypeSystemExtension()
+	}
+
+	start := p.tok.Position
+	desc := p.parseDescription()
+	if !p.peek(gogqllexer.Name) {
+		p.unexpected()
+		return nil
+	}
+
+	switch p.tok.Value {
+	case "schema":
+		return p.parseSchemaDefinition(start, desc)
+	case "scalar":
+		return p.parseScalarTypeDefinition(start, desc)
+	case "type":
+		return p.parseObjectTypeDefinition(start, desc)
+	case "interface":
+		return p.parseInterfaceTypeDefinition(start, desc)
+	case "union":
+		return p.parseUnionTypeDefinition(start, desc)
+	case "enum":
+		return p.parseEnumTypeDefinition(start, desc)
+	case "input":
+		return p.parseInputObjectTypeDefinition(start, desc)
+	case "directive":
+		return p.parseDirectiveDefinition(start, desc)
+	default:
+		p.unexpected()
+		return nil
+	}
+}
+
+// https://spec.graphql.org/October2021/#Description
+func (p *parser) parseDescription() *ast.StringValue {
+	if p.peek(gogqllexer.String) || p.peek(gogqllexer.BlockString) {
+		return p.parseStringValue()
+	}
+
+	return nil
+}
+
+// https://spec.graphql.org/October2021/#SchemaDefinition
+func (p *parser) parseSchemaDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.SchemaDefinition {
+	p.expectKeyword("schema")
+
+	def := &ast.SchemaDefinition{
+		Description: desc,
+		Position:    start,
+	}
+	def.Directives = p.parseDirectives(true)
+	p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+		def.OperationTypes = append(def.OperationTypes, p.parseOperationTypeDefinition())
+	})
+
+	return def
+}
+
+// https://spec.graphql.org/October2021/#RootOperationTypeDefinition
+func (p *parser) parseOperationTypeDefinition() *ast.OperationTypeDefinition {
+	def := &ast.OperationTypeDefinition{
+		Position: p.tok.Position,
+	}
+	def.Operation = p.parseOperationType()
+	p.expect(gogqllexer.Colon)
+	def.Type = p.parseNamedType()
+
+	return def
+}
+
+// https://spec.graphql.org/October2021/#ScalarTypeDefinition
+func (p *parser) parseScalarTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.ScalarTypeDefinition {
+	p.expectKeyword("scalar")
+
+	return &ast.ScalarTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Directives:  p.parseDirectives(true),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#ObjectTypeDefinition
+func (p *parser) parseObjectTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.ObjectTypeDefinition {
+	p.expectKeyword("type")
+
+	return &ast.ObjectTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Interfaces:  p.parseImplementsInterfaces(),
+		Directives:  p.parseDirectives(true),
+		Fields:      p.parseFieldsDefinition(),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#InterfaceTypeDefinition
+func (p *parser) parseInterfaceTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.InterfaceTypeDefinition {
+	p.expectKeyword("interface")
+
+	return &ast.InterfaceTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Interfaces:  p.parseImplementsInterfaces(),
+		Directives:  p.parseDirectives(true),
+		Fields:      p.parseFieldsDefinition(),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#UnionTypeDefinition
+func (p *parser) parseUnionTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.UnionTypeDefinition {
+	p.expectKeyword("union")
+
+	return &ast.UnionTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Directives:  p.parseDirectives(true),
+		Types:       p.parseUnionMemberTypes(),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#EnumTypeDefinition
+func (p *parser) parseEnumTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.EnumTypeDefinition {
+	p.expectKeyword("enum")
+
+	return &ast.EnumTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Directives:  p.parseDirectives(true),
+		Values:      p.parseEnumValuesDefinition(),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#InputObjectTypeDefinition
+func (p *parser) parseInputObjectTypeDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.InputObjectTypeDefinition {
+	p.expectKeyword("input")
+
+	return &ast.InputObjectTypeDefinition{
+		Description: desc,
+		Name:        p.parseName(),
+		Directives:  p.parseDirectives(true),
+		Fields:      p.parseInputFieldsDefinition(),
+		Position:    start,
+	}
+}
+
+// https://spec.graphql.org/October2021/#DirectiveDefinition
+func (p *parser) parseDirectiveDefinition(start gogqllexer.Position, desc *ast.StringValue) *ast.DirectiveDefinition {
+	p.expectKeyword("directive")
+	p.expect(gogqllexer.At)
+
+	def := &ast.DirectiveDefinition{
+		Description: desc,
+		Position:    start,
+	}
+	def.Name = p.parseName()
+	def.Arguments = p.parseArgumentsDefinition()
+	if p.peekKeyword("repeatable") {
+		p.next()
+		def.Repeatable = true
+	}
+	p.expectKeyword("on")
+	def.Locations = p.parseDirectiveLocations()
+
+	return def
+}
+
+// https://spec.graphql.org/October2021/#DirectiveLocations
+func (p *parser) parseDirectiveLocations() []*ast.Name {
+	var locations []*ast.Name
+
+	p.skip(gogqllexer.Pipe)
+	for p.err == nil {
+		if p.peek(gogqllexer.Name) && !directiveLocations[p.tok.Value] {
+			p.unexpected()
+			return nil
+		}
+		locations = append(locations, p.parseName())
+		if !p.skip(gogqllexer.Pipe) {
+			break
+		}
+	}
+
+	return locations
+}
+
+// https://spec.graphql.org/October2021/#DirectiveLocation
+var directiveLocations = map[string]bool{
+	// https://spec.graphql.org/October2021/#ExecutableDirectiveLocation
+	"QUERY":               true,
+	"MUTATION":            true,
+	"SUBSCRIPTION":        true,
+	"FIELD":               true,
+	"FRAGMENT_DEFINITION": true,
+	"FRAGMENT_SPREAD":     true,
+	"INLINE_FRAGMENT":     true,
+	"VARIABLE_DEFINITION": true,
+	// https://spec.graphql.org/October2021/#TypeSystemDirectiveLocation
+	"SCHEMA":                 true,
+	"SCALAR":                 true,
+	"OBJECT":                 true,
+	"FIELD_DEFINITION":       true,
+	"ARGUMENT_DEFINITION":    true,
+	"INTERFACE":              true,
+	"UNION":                  true,
+	"ENUM":                   true,
+	"ENUM_VALUE":             true,
+	"INPUT_OBJECT":           true,
+	"INPUT_FIELD_DEFINITION": true,
+}
+
+// https://spec.graphql.org/October2021/#ImplementsInterfaces
+func (p *parser) parseImplementsInterfaces() []*ast.NamedType {
+	if !p.peekKeyword("implements") {
+		return nil
+	}
+	p.next()
+
+	var types []*ast.NamedType
+	p.skip(gogqllexer.Amp)
+	for p.err == nil {
+		types = append(types, p.parseNamedType())
+		if !p.skip(gogqllexer.Amp) {
+			break
+		}
+	}
+
+	return types
+}
+
+// https://spec.graphql.org/October2021/#FieldsDefinition
+func (p *parser) parseFieldsDefinition() []*ast.FieldDefinition {
+	if !p.peek(gogqllexer.BraceL) {
+		return nil
+	}
+
+	var fields []*ast.FieldDefinition
+	p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+		start := p.tok.Position
+		def := &ast.FieldDefinition{
+			Description: p.parseDescription(),
+			Position:    start,
+		}
+		def.Name = p.parseName()
+		def.Arguments = p.parseArgumentsDefinition()
+		p.expect(gogqllexer.Colon)
+		def.Type = p.parseType()
+		def.Directives = p.parseDirectives(true)
+		fields = append(fields, def)
+	})
+
+	return fields
+}
+
+// https://spec.graphql.org/October2021/#ArgumentsDefinition
+func (p *parser) parseArgumentsDefinition() []*ast.InputValueDefinition {
+	if !p.peek(gogqllexer.ParenL) {
+		return nil
+	}
+
+	var args []*ast.InputValueDefinition
+	p.many(gogqllexer.ParenL, gogqllexer.ParenR, func() {
+		args = append(args, p.parseInputValueDefinition())
+	})
+
+	return args
+}
+
+// https://spec.graphql.org/October2021/#InputFieldsDefinition
+func (p *parser) parseInputFieldsDefinition() []*ast.InputValueDefinition {
+	if !p.peek(gogqllexer.BraceL) {
+		return nil
+	}
+
+	var fields []*ast.InputValueDefinition
+	p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+		fields = append(fields, p.parseInputValueDefinition())
+	})
+
+	return fields
+}
+
+// https://spec.graphql.org/October2021/#InputValueDefinition
+func (p *parser) parseInputValueDefinition() *ast.InputValueDefinition {
+	start := p.tok.Position
+	def := &ast.InputValueDefinition{
+		Description: p.parseDescription(),
+		Position:    start,
+	}
+	def.Name = p.parseName()
+	p.expect(gogqllexer.Colon)
+	def.Type = p.parseType()
+	if p.skip(gogqllexer.Equal) {
+		def.DefaultValue = p.parseValue(true)
+	}
+	def.Directives = p.parseDirectives(true)
+
+	return def
+}
+
+// https://spec.graphql.org/October2021/#UnionMemberTypes
+func (p *parser) parseUnionMemberTypes() []*ast.NamedType {
+	if !p.skip(gogqllexer.Equal) {
+		return nil
+	}
+
+	var types []*ast.NamedType
+	p.skip(gogqllexer.Pipe)
+	for p.err == nil {
+		types = append(types, p.parseNamedType())
+		if !p.skip(gogqllexer.Pipe) {
+			break
+		}
+	}
+
+	return types
+}
+
+// https://spec.graphql.org/October2021/#EnumValuesDefinition
+func (p *parser) parseEnumValuesDefinition() []*ast.EnumValueDefinition {
+	if !p.peek(gogqllexer.BraceL) {
+		return nil
+	}
+
+	var values []*ast.EnumValueDefinition
+	p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+		start := p.tok.Position
+		def := &ast.EnumValueDefinition{
+			Description: p.parseDescription(),
+			Position:    start,
+		}
+		if p.peekKeyword("true") || p.peekKeyword("false") || p.peekKeyword("null") {
+			p.errorf("unexpected Name %q, expected enum value", p.tok.Value)
+			return
+		}
+		def.Name = p.parseName()
+		def.Directives = p.parseDirectives(true)
+		values = append(values, def)
+	})
+
+	return values
+}
+
+// https://spec.graphql.org/October2021/#TypeSystemExtension
+func (p *parser) parseTypeSystemExtension() ast.Definition {
+	start := p.expectKeyword("extend").Position
+	if !p.peek(gogqllexer.Name) {
+		p.unexpected()
+		return nil
+	}
+
+	switch p.tok.Value {
+	case "schema":
+		return p.parseSchemaExtension(start)
+	case "scalar":
+		return p.parseScalarTypeExtension(start)
+	case "type":
+		return p.parseObjectTypeExtension(start)
+	case "interface":
+		return p.parseInterfaceTypeExtension(start)
+	case "union":
+		return p.parseUnionTypeExtension(start)
+	case "enum":
+		return p.parseEnumTypeExtension(start)
+	case "input":
+		return p.parseInputObjectTypeExtension(start)
+	default:
+		p.unexpected()
+		return nil
+	}
+}
+
+// https://spec.graphql.org/October2021/#SchemaExtension
+func (p *parser) parseSchemaExtension(start gogqllexer.Position) *ast.SchemaExtension {
+	p.expectKeyword("schema")
+
+	ext := &ast.SchemaExtension{
+		Position: start,
+	}
+	ext.Directives = p.parseDirectives(true)
+	if p.peek(gogqllexer.BraceL) {
+		p.many(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+			ext.OperationTypes = append(ext.OperationTypes, p.parseOperationTypeDefinition())
+		})
+	}
+	if len(ext.Directives) == 0 && len(ext.OperationTypes) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#ScalarTypeExtension
+func (p *parser) parseScalarTypeExtension(start gogqllexer.Position) *ast.ScalarTypeExtension {
+	p.expectKeyword("scalar")
+
+	ext := &ast.ScalarTypeExtension{
+		Name:       p.parseName(),
+		Directives: p.parseDirectives(true),
+		Position:   start,
+	}
+	if len(ext.Directives) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#ObjectTypeExtension
+func (p *parser) parseObjectTypeExtension(start gogqllexer.Position) *ast.ObjectTypeExtension {
+	p.expectKeyword("type")
+
+	ext := &ast.ObjectTypeExtension{
+		Name:       p.parseName(),
+		Interfaces: p.parseImplementsInterfaces(),
+		Directives: p.parseDirectives(true),
+		Fields:     p.parseFieldsDefinition(),
+		Position:   start,
+	}
+	if len(ext.Interfaces) == 0 && len(ext.Directives) == 0 && len(ext.Fields) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#InterfaceTypeExtension
+func (p *parser) parseInterfaceTypeExtension(start gogqllexer.Position) *ast.InterfaceTypeExtension {
+	p.expectKeyword("interface")
+
+	ext := &ast.InterfaceTypeExtension{
+		Name:       p.parseName(),
+		Interfaces: p.parseImplementsInterfaces(),
+		Directives: p.parseDirectives(true),
+		Fields:     p.parseFieldsDefinition(),
+		Position:   start,
+	}
+	if len(ext.Interfaces) == 0 && len(ext.Directives) == 0 && len(ext.Fields) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#UnionTypeExtension
+func (p *parser) parseUnionTypeExtension(start gogqllexer.Position) *ast.UnionTypeExtension {
+	p.expectKeyword("union")
+
+	ext := &ast.UnionTypeExtension{
+		Name:       p.parseName(),
+		Directives: p.parseDirectives(true),
+		Types:      p.parseUnionMemberTypes(),
+		Position:   start,
+	}
+	if len(ext.Directives) == 0 && len(ext.Types) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#EnumTypeExtension
+func (p *parser) parseEnumTypeExtension(start gogqllexer.Position) *ast.EnumTypeExtension {
+	p.expectKeyword("enum")
+
+	ext := &ast.EnumTypeExtension{
+		Name:       p.parseName(),
+		Directives: p.parseDirectives(true),
+		Values:     p.parseEnumValuesDefinition(),
+		Position:   start,
+	}
+	if len(ext.Directives) == 0 && len(ext.Values) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
+
+// https://spec.graphql.org/October2021/#InputObjectTypeExtension
+func (p *parser) parseInputObjectTypeExtension(start gogqllexer.Position) *ast.InputObjectTypeExtension {
+	p.expectKeyword("input")
+
+	ext := &ast.InputObjectTypeExtension{
+		Name:       p.parseName(),
+		Directives: p.parseDirectives(true),
+		Fields:     p.parseInputFieldsDefinition(),
+		Position:   start,
+	}
+	if len(ext.Directives) == 0 && len(ext.Fields) == 0 {
+		p.unexpected()
+	}
+
+	return ext
+}
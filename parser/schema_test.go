@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want *ast.Document
+	}{
+		{
+			name: "object type with description, interfaces and arguments",
+			src:  `"d" type T implements & A & B @k { f(a: Int = 1): [T!] }`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.ObjectTypeDefinition{
+						Description: &ast.StringValue{Value: "d", Raw: `"d"`, Position: pos(1, 1)},
+						Name:        &ast.Name{Value: "T", Position: pos(1, 10)},
+						Interfaces: []*ast.NamedType{
+							{Name: &ast.Name{Value: "A", Position: pos(1, 25)}, Position: pos(1, 25)},
+							{Name: &ast.Name{Value: "B", Position: pos(1, 29)}, Position: pos(1, 29)},
+						},
+						Directives: []*ast.Directive{
+							{Name: &ast.Name{Value: "k", Position: pos(1, 32)}, Position: pos(1, 31)},
+						},
+						Fields: []*ast.FieldDefinition{
+							{
+								Name: &ast.Name{Value: "f", Position: pos(1, 36)},
+								Arguments: []*ast.InputValueDefinition{
+									{
+										Name: &ast.Name{Value: "a", Position: pos(1, 38)},
+										Type: &ast.NamedType{
+											Name:     &ast.Name{Value: "Int", Position: pos(1, 41)},
+											Position: pos(1, 41),
+										},
+										DefaultValue: &ast.IntValue{Value: "1", Position: pos(1, 47)},
+										Position:     pos(1, 38),
+									},
+								},
+								Type: &ast.ListType{
+									Type: &ast.NonNullType{
+										Type: &ast.NamedType{
+											Name:     &ast.Name{Value: "T", Position: pos(1, 52)},
+											Position: pos(1, 52),
+										},
+										Position: pos(1, 52),
+									},
+									Position: pos(1, 51),
+								},
+								Position: pos(1, 36),
+							},
+						},
+						Position: pos(1, 1),
+					},
+				},
+			},
+		},
+		{
+			name: "schema, union and directive definitions",
+			src: `schema { query: Q }
+union U = | A | B
+directive @d(a: Int) repeatable on | FIELD | OBJECT`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.SchemaDefinition{
+						OperationTypes: []*ast.OperationTypeDefinition{
+							{
+								Operation: ast.Query,
+								Type: &ast.NamedType{
+									Name:     &ast.Name{Value: "Q", Position: pos(1, 17)},
+									Position: pos(1, 17),
+								},
+								Position: pos(1, 10),
+							},
+						},
+						Position: pos(1, 1),
+					},
+					&ast.UnionTypeDefinition{
+						Name: &ast.Name{Value: "U", Position: pos(2, 27)},
+						Types: []*ast.NamedType{
+							{Name: &ast.Name{Value: "A", Position: pos(2, 33)}, Position: pos(2, 33)},
+							{Name: &ast.Name{Value: "B", Position: pos(2, 37)}, Position: pos(2, 37)},
+						},
+						Position: pos(2, 21),
+					},
+					&ast.DirectiveDefinition{
+						Name: &ast.Name{Value: "d", Position: pos(3, 50)},
+						Arguments: []*ast.InputValueDefinition{
+							{
+								Name: &ast.Name{Value: "a", Position: pos(3, 52)},
+								Type: &ast.NamedType{
+									Name:     &ast.Name{Value: "Int", Position: pos(3, 55)},
+									Position: pos(3, 55),
+								},
+								Position: pos(3, 52),
+							},
+						},
+						Repeatable: true,
+						Locations: []*ast.Name{
+							{Value: "FIELD", Position: pos(3, 76)},
+							{Value: "OBJECT", Position: pos(3, 84)},
+						},
+						Position: pos(3, 39),
+					},
+				},
+			},
+		},
+		{
+			name: "extensions",
+			src:  `extend schema @a extend scalar S @b extend enum E { V } extend input I { f: Int }`,
+			want: &ast.Document{
+				Position: pos(1, 1),
+				Definitions: []ast.Definition{
+					&ast.SchemaExtension{
+						Directives: []*ast.Directive{
+							{Name: &ast.Name{Value: "a", Position: pos(1, 16)}, Position: pos(1, 15)},
+						},
+						Position: pos(1, 1),
+					},
+					&ast.ScalarTypeExtension{
+						Name: &ast.Name{Value: "S", Position: pos(1, 32)},
+						Directives: []*ast.Directive{
+							{Name: &ast.Name{Value: "b", Position: pos(1, 35)}, Position: pos(1, 34)},
+						},
+						Position: pos(1, 18),
+					},
+					&ast.EnumTypeExtension{
+						Name: &ast.Name{Value: "E", Position: pos(1, 49)},
+						Values: []*ast.EnumValueDefinition{
+							{Name: &ast.Name{Value: "V", Position: pos(1, 53)}, Position: pos(1, 53)},
+						},
+						Position: pos(1, 37),
+					},
+					&ast.InputObjectTypeExtension{
+						Name: &ast.Name{Value: "I", Position: pos(1, 70)},
+						Fields: []*ast.InputValueDefinition{
+							{
+								Name: &ast.Name{Value: "f", Position: pos(1, 74)},
+								Type: &ast.NamedType{
+									Name:     &ast.Name{Value: "Int", Position: pos(1, 77)},
+									Position: pos(1, 77),
+								},
+								Position: pos(1, 74),
+							},
+						},
+						Position: pos(1, 57),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchema(gogqllexer.New(strings.NewReader(tt.src)))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSchema_Definitions(t *testing.T) {
+	src := `
+"""
+description
+"""
+scalar Date
+type Query { a: String }
+interface Node implements Base { id: ID! }
+union SearchResult = A | B
+enum Color { RED GREEN }
+input Filter { q: String = "x" @d }
+directive @auth on FIELD_DEFINITION
+extend type Query { b: Int }
+extend interface Node @d
+extend union SearchResult = C
+`
+	got, err := ParseSchema(gogqllexer.New(strings.NewReader(src)))
+	assert.NoError(t, err)
+
+	want := []ast.Definition{
+		&ast.ScalarTypeDefinition{},
+		&ast.ObjectTypeDefinition{},
+		&ast.InterfaceTypeDefinition{},
+		&ast.UnionTypeDefinition{},
+		&ast.EnumTypeDefinition{},
+		&ast.InputObjectTypeDefinition{},
+		&ast.DirectiveDefinition{},
+		&ast.ObjectTypeExtension{},
+		&ast.InterfaceTypeExtension{},
+		&ast.UnionTypeExtension{},
+	}
+	if assert.Len(t, got.Definitions, len(want)) {
+		for i := range want {
+			assert.IsType(t, want[i], got.Definitions[i])
+		}
+	}
+}
+
+func TestParseSchema_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		wantMessage string
+		wantPos     gogqllexer.Position
+	}{
+		{
+			name:        "unknown definition",
+			src:         "query { a }",
+			wantMessage: `unexpected Name "query"`,
+			wantPos:     pos(1, 1),
+		},
+		{
+			name:        "empty extension",
+			src:         "extend scalar S",
+			wantMessage: "unexpected <EOF>",
+			wantPos:     pos(1, 15),
+		},
+		{
+			name:        "unknown directive location",
+			src:         "directive @d on FIELD | NOWHERE",
+			wantMessage: `unexpected Name "NOWHERE"`,
+			wantPos:     pos(1, 25),
+		},
+		{
+			name:        "reserved enum value",
+			src:         "enum E { true }",
+			wantMessage: `unexpected Name "true", expected enum value`,
+			wantPos:     pos(1, 10),
+		},
+		{
+			name:        "empty fields definition",
+			src:         "type T {}",
+			wantMessage: `expected Name, found "}"`,
+			wantPos:     pos(1, 9),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchema(gogqllexer.New(strings.NewReader(tt.src)))
+
+			var got *Error
+			if assert.ErrorAs(t, err, &got) {
+				assert.Equal(t, tt.wantMessage, got.Message)
+				assert.Equal(t, tt.wantPos, got.Position)
+			}
+		})
+	}
+}
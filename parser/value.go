@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/ast"
+)
+
+// https://spec.graphql.org/October2021/#Value
+func (p *parser) parseValue(isConst bool) ast.Value {
+	t := p.tok
+
+	switch t.Kind {
+	case gogqllexer.Dollar:
+		if isConst {
+			p.errorf("unexpected variable in constant value")
+			return nil
+		}
+		return p.parseVariable()
+	case gogqllexer.Int:
+		p.next()
+		return &ast.IntValue{
+			Value:    t.Value,
+			Position: t.Position,
+		}
+	case gogqllexer.Float:
+		p.next()
+		return &ast.FloatValue{
+			Value:    t.Value,
+			Position: t.Position,
+		}
+	case gogqllexer.String, gogqllexer.BlockString:
+		return p.parseStringValue()
+	case gogqllexer.Name:
+		p.next()
+		switch t.Value {
+		case "true", "false":
+			return &ast.BooleanValue{
+				Value:    t.Value == "true",
+				Position: t.Position,
+			}
+		case "null":
+			return &ast.NullValue{
+				Position: t.Position,
+			}
+		default:
+			return &ast.EnumValue{
+				Value:    t.Value,
+				Position: t.Position,
+			}
+		}
+	case gogqllexer.BracketL:
+		list := &ast.ListValue{
+			Position: t.Position,
+		}
+		p.some(gogqllexer.BracketL, gogqllexer.BracketR, func() {
+			list.Values = append(list.Values, p.parseValue(isConst))
+		})
+		return list
+	case gogqllexer.BraceL:
+		obj := &ast.ObjectValue{
+			Position: t.Position,
+		}
+		p.some(gogqllexer.BraceL, gogqllexer.BraceR, func() {
+			f := &ast.ObjectField{
+				Position: p.tok.Position,
+			}
+			f.Name = p.parseName()
+			p.expect(gogqllexer.Colon)
+			f.Value = p.parseValue(isConst)
+			obj.Fields = append(obj.Fields, f)
+		})
+		return obj
+	default:
+		p.unexpected()
+		return nil
+	}
+}
+
+// https://spec.graphql.org/October2021/#StringValue
+func (p *parser) parseStringValue() *ast.StringValue {
+	t := p.next()
+
+	value, err := t.StringValue()
+	if err != nil && p.err == nil {
+		p.err = &Error{
+			Message:  err.Error(),
+			Position: t.Position,
+			Span:     t.Span,
+			Err:      err,
+		}
+	}
+
+	return &ast.StringValue{
+		Value:    value,
+		Raw:      t.Value,
+		Block:    t.Kind == gogqllexer.BlockString,
+		Position: t.Position,
+	}
+}
+
+// https://spec.graphql.org/October2021/#Type
+func (p *parser) parseType() ast.Type {
+	var typ ast.Type
+
+	if p.peek(gogqllexer.BracketL) {
+		start := p.next()
+		inner := p.parseType()
+		p.expect(gogqllexer.BracketR)
+		typ = &ast.ListType{
+			Type:     inner,
+			Position: start.Position,
+		}
+	} else {
+		typ = p.parseNamedType()
+	}
+
+	if p.peek(gogqllexer.Bang) {
+		p.next()
+		return &ast.NonNullType{
+			Type:     typ,
+			Position: typ.GetPosition(),
+		}
+	}
+
+	return typ
+}
+
+// https://spec.graphql.org/October2021/#NamedType
+func (p *parser) parseNamedType() *ast.NamedType {
+	pos := p.tok.Position
+
+	return &ast.NamedType{
+		Name:     p.parseName(),
+		Position: pos,
+	}
+}
+
+// https://spec.graphql.org/October2021/#Directives
+func (p *parser) parseDirectives(isConst bool) []*ast.Directive {
+	var directives []*ast.Directive
+	for p.peek(gogqllexer.At) {
+		start := p.next()
+		directives = append(directives, &ast.Directive{
+			Name:      p.parseName(),
+			Arguments: p.parseArguments(isConst),
+			Position:  start.Position,
+		})
+	}
+
+	return directives
+}
@@ -0,0 +1,68 @@
+package persisted
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/Sntree2mi8/gogqllexer/printer"
+)
+
+// Version is the persistedQuery extension version of Apollo automatic persisted queries.
+const Version = 1
+
+// Extension is the value of extensions.persistedQuery in a GraphQL request.
+// https://www.apollographql.com/docs/apollo-server/performance/apq
+type Extension struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
+}
+
+// Normalize returns the canonical serialization of document that is hashed by Hash.
+// Ignored tokens, including comments and commas, are dropped and the remaining tokens
+// are separated by the least whitespace needed to lex them back.
+func Normalize(document string) (string, error) {
+	tokens, err := gogqllexer.All(document)
+	if err != nil {
+		return "", err
+	}
+
+	return printer.Sprint(tokens, printer.Minified)
+}
+
+// Hash returns the lowercase hex SHA-256 digest of the normalized document.
+// Documents that differ only in ignored tokens have the same hash.
+func Hash(document string) (string, error) {
+	normalized, err := Normalize(document)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewExtension returns the persistedQuery extension identifying document.
+// A client sending it must send the normalized document when the server asks for the full query,
+// so that the server computes the same hash.
+func NewExtension(document string) (*Extension, error) {
+	hash, err := Hash(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Extension{
+		Version:    Version,
+		SHA256Hash: hash,
+	}, nil
+}
+
+// Verify reports whether hash identifies document.
+func Verify(document, hash string) (bool, error) {
+	want, err := Hash(document)
+	if err != nil {
+		return false, err
+	}
+
+	return want == hash, nil
+}
@@ -0,0 +1,73 @@
+package persisted
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	// sha256 of "query Q($id:ID!){user(id:$id){name}}"
+	want := "936f33c9cc98805f928c6f4b6f15416439d26fac38e1f0afcae3287a6ba03a82"
+
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "minified",
+			src:  "query Q($id:ID!){user(id:$id){name}}",
+		},
+		{
+			name: "whitespace and commas",
+			src:  "query Q( $id : ID! , ) {\n\tuser(id: $id,) {\r\n name ,\n }\n}\n",
+		},
+		{
+			name: "comments and byte order mark",
+			src:  "\uFEFF# get a user\nquery Q($id: ID!) { # by id\n  user(id: $id) { name }\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Hash(tt.src)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestHash_Error(t *testing.T) {
+	_, err := Hash(`{ a(s: "unterminated) }`)
+
+	var lexErr *gogqllexer.LexError
+	assert.True(t, errors.As(err, &lexErr))
+	assert.Equal(t, gogqllexer.ErrUnterminatedString, lexErr.Code)
+}
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize("query { a(s: \"x, y\") b c: d(n: 1 m: 2) }")
+	assert.NoError(t, err)
+	assert.Equal(t, `query{a(s:"x, y")b c:d(n:1 m:2)}`, got)
+}
+
+func TestNewExtension(t *testing.T) {
+	ext, err := NewExtension("{ a }")
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(map[string]any{"persistedQuery": ext})
+	assert.NoError(t, err)
+	// sha256 of "{a}"
+	assert.JSONEq(t, `{"persistedQuery":{"version":1,"sha256Hash":"460c3a93211614ac783c0f1d1bbbcb45a6da87d6421b5c0771772588f1015ff8"}}`, string(b))
+
+	ok, err := Verify("{\n  a\n}", ext.SHA256Hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("{ b }", ext.SHA256Hash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,310 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Sntree2mi8/gogqllexer"
+)
+
+type Mode int
+
+const (
+	// Minified writes the least whitespace needed for the output to lex back to the same tokens.
+	// Comments are dropped.
+	Minified Mode = iota
+	// Canonical puts definitions, selections and fields on their own lines indented by two spaces,
+	// separates arguments and list items with ", " and keeps comments.
+	Canonical
+)
+
+// isDefinitionKeyword reports whether name is a keyword that starts a definition.
+func isDefinitionKeyword(name string) bool {
+	switch gogqllexer.Keywords[name] {
+	case gogqllexer.OperationKeyword, gogqllexer.FragmentKeyword, gogqllexer.TypeSystemKeyword:
+		return true
+	default:
+		return false
+	}
+}
+
+type scope int
+
+const (
+	// selection sets, field and enum value definitions
+	scopeBlock scope = iota
+	// object values
+	scopeObject
+	// arguments and variable definitions
+	scopeArgs
+	// list values and list types
+	scopeList
+)
+
+type printer struct {
+	b strings.Builder
+
+	scopes []scope
+	// prev and prevprev are the last two printed tokens that are not comments.
+	prev, prevprev gogqllexer.Token
+	// last is the last printed token, including comments.
+	last         gogqllexer.Token
+	started      bool
+	afterComment bool
+}
+
+// Fprint writes tokens to w in the given mode.
+// Printing stops at the first EOF token, and an Invalid token is reported as an error.
+func Fprint(w io.Writer, tokens []gogqllexer.Token, mode Mode) error {
+	s, err := Sprint(tokens, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// Sprint returns tokens printed in the given mode.
+func Sprint(tokens []gogqllexer.Token, mode Mode) (string, error) {
+	p := &printer{}
+	for _, t := range tokens {
+		if t.Kind == gogqllexer.EOF {
+			break
+		}
+		if t.Kind == gogqllexer.Invalid {
+			return "", fmt.Errorf("%d:%d: cannot print invalid token", t.Span.Start.Line, t.Span.Start.Column)
+		}
+
+		if mode == Minified {
+			p.minified(t)
+		} else {
+			p.canonical(t)
+		}
+	}
+	if mode == Canonical && p.started {
+		p.b.WriteByte('\n')
+	}
+
+	return p.b.String(), nil
+}
+
+func (p *printer) minified(t gogqllexer.Token) {
+	if t.Kind == gogqllexer.Comment {
+		return
+	}
+	if p.started && needsSeparator(p.prev, t) {
+		p.b.WriteByte(' ')
+	}
+	p.write(t)
+}
+
+func (p *printer) canonical(t gogqllexer.Token) {
+	if t.Kind == gogqllexer.Comment {
+		switch {
+		case !p.started:
+		case !p.afterComment && t.Span.Start.Line == p.last.Span.End.Line:
+			p.b.WriteByte(' ')
+		case len(p.scopes) == 0 && p.last.Kind == gogqllexer.BraceR:
+			// a comment leading the next definition
+			p.b.WriteString("\n\n")
+		default:
+			p.b.WriteString(p.newline(0))
+		}
+		p.write(t)
+		return
+	}
+
+	sep := p.separator(t)
+	if sep == "" && p.started && needsSeparator(p.prev, t) {
+		sep = " "
+	}
+	if p.afterComment && (sep == "\n\n" && p.prev.Kind == gogqllexer.BraceR || !strings.HasPrefix(sep, "\n")) {
+		sep = p.newline(0)
+	}
+	p.b.WriteString(sep)
+
+	switch t.Kind {
+	case gogqllexer.BraceL:
+		if p.inline() {
+			p.scopes = append(p.scopes, scopeObject)
+		} else {
+			p.scopes = append(p.scopes, scopeBlock)
+		}
+	case gogqllexer.ParenL:
+		p.scopes = append(p.scopes, scopeArgs)
+	case gogqllexer.BracketL:
+		p.scopes = append(p.scopes, scopeList)
+	case gogqllexer.BraceR, gogqllexer.ParenR, gogqllexer.BracketR:
+		if len(p.scopes) > 0 {
+			p.scopes = p.scopes[:len(p.scopes)-1]
+		}
+	}
+	p.write(t)
+}
+
+func (p *printer) write(t gogqllexer.Token) {
+	if text := t.Kind.Text(); text != "" {
+		p.b.WriteString(text)
+	} else {
+		p.b.WriteString(t.Value)
+	}
+
+	p.started = true
+	p.last = t
+	p.afterComment = t.Kind == gogqllexer.Comment
+	if !p.afterComment {
+		p.prevprev, p.prev = p.prev, t
+	}
+}
+
+// separator returns the whitespace written before t in Canonical mode.
+func (p *printer) separator(t gogqllexer.Token) string {
+	if !p.started {
+		return ""
+	}
+
+	top, nested := p.top()
+	switch t.Kind {
+	case gogqllexer.BraceR:
+		if nested && top == scopeBlock && p.prev.Kind != gogqllexer.BraceL {
+			return p.newline(-1)
+		}
+		return ""
+	case gogqllexer.ParenR, gogqllexer.BracketR:
+		return ""
+	}
+
+	if !nested {
+		switch {
+		case p.prev.Kind == gogqllexer.String || p.prev.Kind == gogqllexer.BlockString:
+			// a string at the top level is the description of the next definition
+			return "\n"
+		case p.startsDefinition(t):
+			return "\n\n"
+		}
+	}
+
+	switch {
+	case !nested:
+	case top == scopeBlock:
+		if p.prev.Kind == gogqllexer.BraceL || startsSelection(t) && p.endsItem() {
+			return p.newline(0)
+		}
+	default:
+		if startsValue(t) && p.endsItem() {
+			return ", "
+		}
+	}
+
+	switch p.prev.Kind {
+	case gogqllexer.Dollar, gogqllexer.At, gogqllexer.ParenL, gogqllexer.BracketL:
+		return ""
+	case gogqllexer.BraceL:
+		// only object values are still open here, selection sets broke the line above
+		return ""
+	case gogqllexer.Spread:
+		if t.Kind == gogqllexer.Name && t.Value != "on" {
+			return ""
+		}
+	}
+
+	switch t.Kind {
+	case gogqllexer.Colon, gogqllexer.Bang, gogqllexer.ParenL:
+		return ""
+	}
+
+	return " "
+}
+
+func (p *printer) top() (scope, bool) {
+	if len(p.scopes) == 0 {
+		return 0, false
+	}
+	return p.scopes[len(p.scopes)-1], true
+}
+
+// inline reports whether a BraceL printed now opens an object value.
+func (p *printer) inline() bool {
+	if top, nested := p.top(); nested && top != scopeBlock {
+		return true
+	}
+	return p.prev.Kind == gogqllexer.Colon || p.prev.Kind == gogqllexer.Equal
+}
+
+func (p *printer) newline(depth int) string {
+	for _, s := range p.scopes {
+		if s == scopeBlock {
+			depth++
+		}
+	}
+	if depth < 0 {
+		depth = 0
+	}
+	return "\n" + strings.Repeat("  ", depth)
+}
+
+// endsItem reports whether the previous token can end a selection, an argument or a value.
+func (p *printer) endsItem() bool {
+	switch p.prev.Kind {
+	case gogqllexer.Name:
+		// the type condition follows "... on"
+		return p.prev.Value != "on" || p.prevprev.Kind != gogqllexer.Spread
+	case gogqllexer.Int, gogqllexer.Float, gogqllexer.String, gogqllexer.BlockString,
+		gogqllexer.ParenR, gogqllexer.BracketR, gogqllexer.BraceR, gogqllexer.Bang:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *printer) startsDefinition(t gogqllexer.Token) bool {
+	if p.prev.Kind == gogqllexer.BraceR {
+		return true
+	}
+	if t.Kind != gogqllexer.Name || !isDefinitionKeyword(t.Value) || !p.endsItem() {
+		return false
+	}
+	// "extend type", "query subscription" and the like name or continue the same definition
+	return p.prev.Kind != gogqllexer.Name || !isDefinitionKeyword(p.prev.Value)
+}
+
+func startsSelection(t gogqllexer.Token) bool {
+	switch t.Kind {
+	case gogqllexer.Name, gogqllexer.Spread, gogqllexer.String, gogqllexer.BlockString:
+		return true
+	default:
+		return false
+	}
+}
+
+func startsValue(t gogqllexer.Token) bool {
+	switch t.Kind {
+	case gogqllexer.Name, gogqllexer.Dollar, gogqllexer.Int, gogqllexer.Float, gogqllexer.String, gogqllexer.BlockString,
+		gogqllexer.BracketL, gogqllexer.BraceL:
+		return true
+	default:
+		return false
+	}
+}
+
+func isWord(k gogqllexer.Kind) bool {
+	return k == gogqllexer.Name || k == gogqllexer.Int || k == gogqllexer.Float
+}
+
+// needsSeparator reports whether prev and next would lex differently when written without whitespace between them.
+func needsSeparator(prev, next gogqllexer.Token) bool {
+	switch {
+	case isWord(prev.Kind) && isWord(next.Kind):
+		// "a b" is not "ab", "a 1" is not "a1", "1 e" is not "1e"
+		return true
+	case (prev.Kind == gogqllexer.Int || prev.Kind == gogqllexer.Float) && next.Kind == gogqllexer.Spread:
+		// "1..." is an invalid number
+		return true
+	case prev.Kind == gogqllexer.String && prev.Value == `""` && (next.Kind == gogqllexer.String || next.Kind == gogqllexer.BlockString):
+		// `"""` opens a block string
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Sntree2mi8/gogqllexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprint(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		minified  string
+		canonical string
+	}{
+		{
+			name:      "shorthand query",
+			src:       "{ a, b }",
+			minified:  "{a b}",
+			canonical: "{\n  a\n  b\n}\n",
+		},
+		{
+			name:      "operation with variables and directives",
+			src:       "query Q($id: ID! = 1 @d, $l: [Int] = [1, 2]) @skip(if: true) { alias: f(id: $id, o: {a: 1.5, b: [x y]}) }",
+			minified:  "query Q($id:ID!=1@d$l:[Int]=[1 2])@skip(if:true){alias:f(id:$id o:{a:1.5 b:[x y]})}",
+			canonical: "query Q($id: ID! = 1 @d, $l: [Int] = [1, 2]) @skip(if: true) {\n  alias: f(id: $id, o: {a: 1.5, b: [x, y]})\n}\n",
+		},
+		{
+			name:      "fragments",
+			src:       "{ ...F ... on T { a } ... @include(if: $x) { b } } fragment F on T { c }",
+			minified:  "{...F...on T{a}...@include(if:$x){b}}fragment F on T{c}",
+			canonical: "{\n  ...F\n  ... on T {\n    a\n  }\n  ... @include(if: $x) {\n    b\n  }\n}\n\nfragment F on T {\n  c\n}\n",
+		},
+		{
+			name:      "adjacent numbers and names",
+			src:       "{ f(a: [1 2 x 1.5 e]) }",
+			minified:  "{f(a:[1 2 x 1.5 e])}",
+			canonical: "{\n  f(a: [1, 2, x, 1.5, e])\n}\n",
+		},
+		{
+			name:      "strings",
+			src:       "{ f(a: \"\" b: \"x\", c: \"\"\"\n  block\n\"\"\") }",
+			minified:  "{f(a:\"\"b:\"x\"c:\"\"\"\n  block\n\"\"\")}",
+			canonical: "{\n  f(a: \"\", b: \"x\", c: \"\"\"\n  block\n\"\"\")\n}\n",
+		},
+		{
+			name:      "empty string followed by string",
+			src:       "{ f(a: [\"\" \"x\"]) }",
+			minified:  "{f(a:[\"\" \"x\"])}",
+			canonical: "{\n  f(a: [\"\", \"x\"])\n}\n",
+		},
+		{
+			name:      "type system definitions",
+			src:       "\"desc\" type T implements A & B @d { \"f\" f(a: Int = 1, b: String): [Int!]! @deprecated g: String } union U = A | B scalar S extend type T { x: Int } directive @d(a: Int) repeatable on FIELD | QUERY",
+			minified:  "\"desc\"type T implements A&B@d{\"f\"f(a:Int=1 b:String):[Int!]!@deprecated g:String}union U=A|B scalar S extend type T{x:Int}directive@d(a:Int)repeatable on FIELD|QUERY",
+			canonical: "\"desc\"\ntype T implements A & B @d {\n  \"f\"\n  f(a: Int = 1, b: String): [Int!]! @deprecated\n  g: String\n}\n\nunion U = A | B\n\nscalar S\n\nextend type T {\n  x: Int\n}\n\ndirective @d(a: Int) repeatable on FIELD | QUERY\n",
+		},
+		{
+			name:      "comments",
+			src:       "# leading\n{ a # trailing\n  # own line\n  b }\n# next\n{ c }",
+			minified:  "{a b}{c}",
+			canonical: "# leading\n{\n  a # trailing\n  # own line\n  b\n}\n\n# next\n{\n  c\n}\n",
+		},
+		{
+			name:      "empty",
+			src:       "",
+			minified:  "",
+			canonical: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := gogqllexer.All(tt.src, gogqllexer.WithComments())
+			assert.NoError(t, err)
+
+			got, err := Sprint(tokens, Minified)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.minified, got)
+
+			got, err = Sprint(tokens, Canonical)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.canonical, got)
+		})
+	}
+}
+
+func TestSprint_RoundTrip(t *testing.T) {
+	srcs := []string{
+		"query Q($a: [Int!]! = [1, -2.5e3]) { a: f(x: $a, y: {z: \"\"}) @d { ...F, ... on T { b } } }",
+		"{ f(a: \"\" b: \"\"\"x\"\"\" c: \"a\"\"b\") }",
+		"type T { f(a: Int = 0 b: Float = 1.0): [T] } enum E { A B C } input I { a: E = A }",
+	}
+
+	for _, mode := range []Mode{Minified, Canonical} {
+		for _, src := range srcs {
+			want, err := gogqllexer.All(src)
+			assert.NoError(t, err)
+
+			out, err := Sprint(want, mode)
+			assert.NoError(t, err)
+
+			got, err := gogqllexer.All(out)
+			assert.NoError(t, err)
+			assert.Equal(t, kindsAndValues(want), kindsAndValues(got), out)
+		}
+	}
+}
+
+func TestFprint(t *testing.T) {
+	tokens, err := gogqllexer.All("{ a }")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Fprint(&buf, tokens, Minified))
+	assert.Equal(t, "{a}", buf.String())
+
+	err = Fprint(&buf, []gogqllexer.Token{{
+		Kind:     gogqllexer.Invalid,
+		Position: gogqllexer.Position{Line: 2, Start: 9},
+		Span:     gogqllexer.Span{Start: gogqllexer.Location{Offset: 8, Line: 2, Column: 3, UTF16Column: 3}},
+	}}, Minified)
+	assert.EqualError(t, err, "2:3: cannot print invalid token")
+}
+
+func kindsAndValues(tokens []gogqllexer.Token) []gogqllexer.Token {
+	out := make([]gogqllexer.Token, len(tokens))
+	for i, t := range tokens {
+		out[i] = gogqllexer.Token{Kind: t.Kind, Value: t.Value}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package gogqllexer
+
+import "strings"
+
+// recoverFromError skips the remainder of the lexeme that caused l.err.
+func (l *Lexer) recoverFromError() {
+	l.syncCursor()
+	start := l.cur.Location
+
+	switch l.err.Code {
+	case ErrLeadingZero, ErrInvalidNumber:
+		l.skipWhile(func(r rune) bool {
+			return l.isNameContinue(r) || r == '.'
+		})
+	case ErrUnterminatedString, ErrInvalidCharacterInString, ErrInvalidEscapeSequence:
+		if strings.HasPrefix(l.err.Text, `"""`) {
+			l.skipBlockString()
+		} else {
+			l.skipString()
+		}
+	}
+
+	l.syncCursor()
+	l.err.Skipped = Span{
+		Start: start,
+		End:   l.cur.Location,
+	}
+}
+
+func (l *Lexer) skipWhile(fn func(r rune) bool) {
+	for {
+		r, err := l.peek()
+		if err != nil || !fn(r) {
+			return
+		}
+		_, _, _ = l.readRune()
+	}
+}
+
+// skipString skips to the closing quote of a string, or to the end of the line.
+func (l *Lexer) skipString() {
+	// the string has already been closed or broken by a line terminator
+	if l.cur.last == '"' && l.cur.Offset-l.lexemeStart > 1 || isLineTerminator(l.cur.last) {
+		return
+	}
+
+	for {
+		r, err := l.peek()
+		if err != nil || isLineTerminator(r) {
+			return
+		}
+		_, _, _ = l.readRune()
+
+		switch r {
+		case '"':
+			return
+		case '\\':
+			if r, err = l.peek(); err == nil && !isLineTerminator(r) {
+				_, _, _ = l.readRune()
+			}
+		}
+	}
+}
+
+// skipBlockString skips to the closing triple quote of a block string, or to the end of input.
+func (l *Lexer) skipBlockString() {
+	quotes := 0
+	for quotes < 3 {
+		r, _, err := l.readRune()
+		if err != nil {
+			return
+		}
+
+		switch r {
+		case '"':
+			quotes++
+		case '\\':
+			// \""" does not close the block string
+			quotes = 0
+			for i := 0; i < 3; i++ {
+				if r, err = l.peek(); err != nil || r != '"' {
+					break
+				}
+				_, _, _ = l.readRune()
+			}
+		default:
+			quotes = 0
+		}
+	}
+}
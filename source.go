@@ -0,0 +1,235 @@
+package gogqllexer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// NewFromString returns a Lexer that scans src directly, decoding runes by index instead of through a RuneScanner.
+// Token values are substrings of src, so lexing does not allocate per token.
+func NewFromString(src string, opts ...Option) *Lexer {
+	l := New(nil, opts...)
+	l.src = src
+	l.fromSource = true
+	l.updateEnd()
+
+	return l
+}
+
+// NewFromBytes is like NewFromString but takes a byte slice.
+// src is copied once, so later changes to it do not affect the returned tokens.
+func NewFromBytes(src []byte, opts ...Option) *Lexer {
+	return NewFromString(string(src), opts...)
+}
+
+// Source is a named GraphQL document. It indexes the starts of its lines,
+// so that offsets can be turned into locations and back, and snippets can be printed for diagnostics.
+type Source struct {
+	// Name is the file name or URI of the document, printed in snippets.
+	Name string
+	Body string
+
+	// lines holds the offsets at which the lines indexed so far start.
+	lines []int
+	// cur is the end of the indexed part of Body.
+	cur cursor
+}
+
+func NewSource(name, body string) *Source {
+	return &Source{
+		Name:  name,
+		Body:  body,
+		lines: []int{0},
+		cur:   newCursor(),
+	}
+}
+
+// Lexer returns a Lexer for the body, like NewFromString, that indexes lines as it reads them.
+func (s *Source) Lexer(opts ...Option) *Lexer {
+	l := NewFromString(s.Body, opts...)
+	l.source = s
+
+	return l
+}
+
+// advance indexes the rune r of size bytes at the end of the indexed part.
+func (s *Source) advance(r rune, size int) {
+	s.cur.advance(r, size)
+	if isLineTerminator(r) {
+		// a '\n' after '\r' moves the start of the line past it
+		s.lines = append(s.lines[:s.cur.Line-1], s.cur.Offset)
+	}
+}
+
+// indexTo indexes the body up to offset, and past a '\n' at offset that ends a line with '\r'.
+func (s *Source) indexTo(offset int) {
+	for s.cur.Offset < len(s.Body) && (s.cur.Offset <= offset || s.cur.last == '\r' && s.Body[s.cur.Offset] == '\n') {
+		r, size := utf8.DecodeRuneInString(s.Body[s.cur.Offset:])
+		s.advance(r, size)
+	}
+}
+
+// LineCount returns the number of lines of the body. An empty body has one line.
+func (s *Source) LineCount() int {
+	s.indexTo(len(s.Body))
+
+	return len(s.lines)
+}
+
+// Location returns the location of the byte offset in the body, which is clamped to the body.
+// An offset inside a line terminator, or inside a rune, is on the line of the next character.
+func (s *Source) Location(offset int) Location {
+	offset = clamp(offset, 0, len(s.Body))
+	s.indexTo(offset)
+
+	line := sort.Search(len(s.lines), func(i int) bool { return s.lines[i] > offset })
+	if offset > 0 && s.Body[offset-1] == '\r' && offset < len(s.Body) && s.Body[offset] == '\n' {
+		// the lexer has already started the next line at the '\n' of "\r\n"
+		return Location{Offset: offset, Line: line + 1, Column: 1, UTF16Column: 1}
+	}
+	start := s.lines[line-1]
+
+	loc := Location{
+		Offset:      offset,
+		Line:        line,
+		Column:      offset - start + 1,
+		UTF16Column: 1,
+	}
+	for _, r := range s.Body[start:offset] {
+		loc.UTF16Column += utf16Len(r)
+	}
+
+	return loc
+}
+
+// Offset returns the byte offset of the 1-based line and byte column,
+// and false when the line does not exist or the column is past its end.
+func (s *Source) Offset(line, column int) (int, bool) {
+	text, ok := s.lineText(line)
+	if !ok || column < 1 || column > len(text)+1 {
+		return 0, false
+	}
+
+	return s.lines[line-1] + column - 1, true
+}
+
+// UTF16Offset is like Offset but takes a column in UTF-16 code units, as used by LSP.
+func (s *Source) UTF16Offset(line, utf16Column int) (int, bool) {
+	text, ok := s.lineText(line)
+	if !ok || utf16Column < 1 {
+		return 0, false
+	}
+
+	column := 1
+	for i, r := range text {
+		if column >= utf16Column {
+			return s.lines[line-1] + i, column == utf16Column
+		}
+		column += utf16Len(r)
+	}
+	if column != utf16Column {
+		return 0, false
+	}
+
+	return s.lines[line-1] + len(text), true
+}
+
+// Line returns the text of the 1-based line n without its line terminator, or "" if there is no such line.
+func (s *Source) Line(n int) string {
+	text, _ := s.lineText(n)
+
+	return text
+}
+
+func (s *Source) lineText(n int) (string, bool) {
+	if n < 1 {
+		return "", false
+	}
+	for len(s.lines) <= n && s.cur.Offset < len(s.Body) {
+		s.indexTo(s.cur.Offset)
+	}
+	if n > len(s.lines) {
+		return "", false
+	}
+
+	text := s.Body[s.lines[n-1]:]
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		text = text[:i]
+	}
+
+	return text, true
+}
+
+// Snippet prints the position of span followed by the lines it covers, with the span underlined by carets:
+//
+//	file.graphql:1:8
+//	1 | { a(x: 01) }
+//	  |        ^^
+func (s *Source) Snippet(span Span) string {
+	start := s.Location(span.Start.Offset)
+	end := s.Location(span.End.Offset)
+
+	var b strings.Builder
+	if s.Name != "" {
+		b.WriteString(s.Name + ":")
+	}
+	fmt.Fprintf(&b, "%d:%d\n", start.Line, start.Column)
+
+	width := len(strconv.Itoa(end.Line))
+	for n := start.Line; n <= end.Line; n++ {
+		text := s.Line(n)
+		from, to := 0, len(text)
+		if n == start.Line {
+			from = start.Column - 1
+		}
+		if n == end.Line {
+			to = end.Column - 1
+		}
+		if n > start.Line && n == end.Line && to == 0 {
+			// the span ends with the line terminator of the previous line
+			break
+		}
+
+		fmt.Fprintf(&b, "%*d | %s\n", width, n, text)
+		fmt.Fprintf(&b, "%*s | %s\n", width, "", underline(text, from, to))
+	}
+
+	return b.String()
+}
+
+// underline returns carets under text[from:to], one per rune, aligned by keeping the tabs before from.
+// An empty range is marked by one caret.
+func underline(text string, from, to int) string {
+	from = clamp(from, 0, len(text))
+	to = clamp(to, from, len(text))
+
+	var b strings.Builder
+	for _, r := range text[:from] {
+		if r == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	carets := utf8.RuneCountInString(text[from:to])
+	if carets == 0 {
+		carets = 1
+	}
+	b.WriteString(strings.Repeat("^", carets))
+
+	return b.String()
+}
+
+func clamp(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	default:
+		return v
+	}
+}
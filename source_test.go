@@ -0,0 +1,352 @@
+package gogqllexer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "query",
+			src:  "query Q($id: ID! = 1) { a: f(id: $id, s: \"s\\u00e9\") @d { ...F } }",
+		},
+		{
+			name: "block string spanning lines",
+			src:  "\"\"\"\r\n  block\n  \"quoted\" \\\"\"\"\n\"\"\" # comment\n-1.5e+10",
+		},
+		{
+			name: "invalid number",
+			src:  "1.a",
+		},
+		{
+			name: "unterminated string",
+			src:  "\"abc",
+		},
+		{
+			name: "non ascii",
+			src:  "\uFEFF\"日本語 😀\" x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := New(strings.NewReader(tt.src), WithComments())
+			source := NewFromString(tt.src, WithComments())
+
+			for {
+				want := scanner.NextToken()
+				got := source.NextToken()
+
+				assert.Equal(t, want, got)
+				assert.Equal(t, scanner.Err(), source.Err())
+				if want.Kind == EOF || want.Kind == Invalid {
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromString_NoAllocation(t *testing.T) {
+	src := strings.Repeat(`query Q($a: [Int!] = [1, 2.5e3]) { f(s: "str", b: """block""") @d { ...F } } `, 10)
+
+	l := NewFromString(src)
+	fresh := *l
+	tokens := 0
+	allocs := testing.AllocsPerRun(1, func() {
+		// every run lexes the whole document, reusing the token buffer allocated by the warm-up run
+		buffer := l.buffer.tokens[:0]
+		*l = fresh
+		l.buffer.tokens = buffer
+		tokens = 0
+		for l.NextToken().Kind != EOF {
+			tokens++
+		}
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, 330, tokens)
+}
+
+func TestSource_Location(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "empty",
+			body: "",
+		},
+		{
+			name: "line terminators",
+			body: "a\nb\rc\r\nd\n\r\r\n\ne\r",
+		},
+		{
+			name: "non ascii",
+			body: "\uFEFF{ a(s: \"\U0001F600 \u00e9\") }\r\n# \U0001F600\n\"\"\"\n\U0001F600\n\"\"\"",
+		},
+		{
+			name: "invalid utf-8",
+			body: "a \xff\n\"\xe3\x81\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexed := NewSource("", tt.body)
+			lexAll(indexed.Lexer(WithErrorRecovery(), WithComments()))
+
+			for _, source := range []*Source{indexed, NewSource("", tt.body)} {
+				c := newCursor()
+				for {
+					loc := source.Location(c.Offset)
+					assert.Equal(t, c.Location, loc)
+
+					if !(c.last == '\r' && c.Offset < len(tt.body) && tt.body[c.Offset] == '\n') {
+						offset, ok := source.Offset(loc.Line, loc.Column)
+						assert.True(t, ok)
+						assert.Equal(t, c.Offset, offset)
+						offset, ok = source.UTF16Offset(loc.Line, loc.UTF16Column)
+						assert.True(t, ok)
+						assert.Equal(t, c.Offset, offset)
+					}
+
+					if c.Offset == len(tt.body) {
+						break
+					}
+					r, size := utf8.DecodeRuneInString(tt.body[c.Offset:])
+					c.advance(r, size)
+				}
+				assert.Equal(t, c.Line, source.LineCount())
+			}
+		})
+	}
+}
+
+func TestSource_Offset(t *testing.T) {
+	source := NewSource("", "ab\r\n\U0001F600c")
+
+	tests := []struct {
+		name   string
+		line   int
+		column int
+		utf16  bool
+		want   int
+		wantOK bool
+	}{
+		{name: "end of line", line: 1, column: 3, want: 2, wantOK: true},
+		{name: "past end of line", line: 1, column: 4},
+		{name: "line 0", line: 0, column: 1},
+		{name: "past last line", line: 3, column: 1},
+		{name: "after emoji", line: 2, column: 5, want: 8, wantOK: true},
+		{name: "utf-16 after emoji", line: 2, column: 3, utf16: true, want: 8, wantOK: true},
+		{name: "utf-16 inside emoji", line: 2, column: 2, utf16: true, want: 4},
+		{name: "utf-16 past end of line", line: 2, column: 5, utf16: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got int
+				ok  bool
+			)
+			if tt.utf16 {
+				got, ok = source.UTF16Offset(tt.line, tt.column)
+			} else {
+				got, ok = source.Offset(tt.line, tt.column)
+			}
+
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSource_Line(t *testing.T) {
+	source := NewSource("", "a\r\n\nb\rc")
+
+	assert.Equal(t, 4, source.LineCount())
+	assert.Equal(t, []string{"", "a", "", "b", "c", ""}, []string{
+		source.Line(0), source.Line(1), source.Line(2), source.Line(3), source.Line(4), source.Line(5),
+	})
+}
+
+func TestSource_Snippet(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceName string
+		body       string
+		start, end int
+		want       string
+	}{
+		{
+			name:       "token",
+			sourceName: "file.graphql",
+			body:       "query {\n  a(x: 01)\n}",
+			start:      15,
+			end:        17,
+			want: "file.graphql:2:8\n" +
+				"2 |   a(x: 01)\n" +
+				"  |        ^^\n",
+		},
+		{
+			name:  "without name",
+			body:  "{ ~ }",
+			start: 2,
+			end:   3,
+			want: "1:3\n" +
+				"1 | { ~ }\n" +
+				"  |   ^\n",
+		},
+		{
+			name:       "empty span at end of input",
+			sourceName: "f",
+			body:       "{ a",
+			start:      3,
+			end:        3,
+			want: "f:1:4\n" +
+				"1 | { a\n" +
+				"  |    ^\n",
+		},
+		{
+			name:       "tabs and non ascii",
+			sourceName: "f",
+			body:       "\t\"\u00e9\u00e9\" \U0001F600",
+			start:      8,
+			end:        12,
+			want: "f:1:9\n" +
+				"1 | \t\"\u00e9\u00e9\" \U0001F600\n" +
+				"  | \t     ^\n",
+		},
+		{
+			name:       "span over lines",
+			sourceName: "f",
+			body:       "# c\n\"\"\"\r\nblock\r\n\"\"\" x",
+			start:      4,
+			end:        19,
+			want: "f:2:1\n" +
+				"2 | \"\"\"\n" +
+				"  | ^^^\n" +
+				"3 | block\n" +
+				"  | ^^^^^\n" +
+				"4 | \"\"\" x\n" +
+				"  | ^^^\n",
+		},
+		{
+			name:       "span ending with a line terminator",
+			sourceName: "f",
+			body:       "\"a\nb",
+			start:      0,
+			end:        3,
+			want: "f:1:1\n" +
+				"1 | \"a\n" +
+				"  | ^^\n",
+		},
+		{
+			name:       "line numbers of different widths",
+			sourceName: "f",
+			body:       strings.Repeat("\n", 8) + "a\nb",
+			start:      8,
+			end:        11,
+			want: "f:9:1\n" +
+				" 9 | a\n" +
+				"   | ^\n" +
+				"10 | b\n" +
+				"   | ^\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewSource(tt.sourceName, tt.body)
+			span := Span{
+				Start: source.Location(tt.start),
+				End:   source.Location(tt.end),
+			}
+
+			assert.Equal(t, tt.want, source.Snippet(span))
+		})
+	}
+}
+
+var benchmarkSource = strings.Repeat(`
+query Q($id: ID!, $first: Int = 10) {
+  node(id: $id) {
+    ... on User {
+      name
+      friends(first: $first, after: "cursor") @include(if: true) {
+        edges { node { id score } }
+      }
+      bio(format: """
+        block string
+      """)
+    }
+  }
+}
+`, 1000)
+
+// benchmarkSchema has long descriptions and comments, whose text the RuneScanner path copies and the string path slices.
+var benchmarkSchema = strings.Repeat(`
+"""
+A user of the service, with a profile and the friends they follow.
+Friends are paginated with the "first" and "after" arguments.
+"""
+type User implements Node & Entity @key(fields: "id") {
+  # the global id, see Node
+  id: ID!
+  "The display name, at most 64 characters."
+  name(locale: String = "en-US"): String @deprecated(reason: "Use profile.displayName instead.")
+  friends(first: Int = 10, after: String, orderBy: [UserOrder!] = [{field: NAME, direction: ASC}]): UserConnection!
+}
+`, 500)
+
+// BenchmarkLexer compares lexing a string through a RuneScanner with lexing it directly with NewFromString.
+func BenchmarkLexer(b *testing.B) {
+	sources := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "Query",
+			src:  benchmarkSource,
+		},
+		{
+			name: "Schema",
+			src:  benchmarkSchema,
+		},
+	}
+	benchmarks := []struct {
+		name string
+		new  func(src string) *Lexer
+	}{
+		{
+			name: "RuneScanner",
+			new: func(src string) *Lexer {
+				return New(strings.NewReader(src))
+			},
+		},
+		{
+			name: "FromString",
+			new: func(src string) *Lexer {
+				return NewFromString(src)
+			},
+		},
+	}
+	for _, source := range sources {
+		for _, bm := range benchmarks {
+			b.Run(source.name+"/"+bm.name, func(b *testing.B) {
+				b.SetBytes(int64(len(source.src)))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					l := bm.new(source.src)
+					for l.NextToken().Kind != EOF {
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package gogqllexer
+
+import "unicode/utf8"
+
+// Spec selects the edition of the GraphQL specification whose lexical rules the lexer follows.
+type Spec int
+
+const (
+	// https://spec.graphql.org/October2021/
+	October2021 Spec = iota
+	// https://spec.graphql.org/draft/
+	Draft
+)
+
+// WithSpec selects the spec edition. The default is October2021.
+func WithSpec(spec Spec) Option {
+	return func(l *Lexer) {
+		l.spec = spec
+	}
+}
+
+// isSourceCharacter reports whether r, read as size bytes, may appear in strings and comments.
+// October2021 accepts any decoded rune for compatibility, while the draft requires
+// a Unicode scalar value and so rejects invalid UTF-8.
+// https://spec.graphql.org/draft/#SourceCharacter
+func (l *Lexer) isSourceCharacter(r rune, size int) bool {
+	if l.spec != Draft {
+		return true
+	}
+	return r != utf8.RuneError || size > 1
+}
+
+// https://spec.graphql.org/October2021/#CommentChar
+// https://spec.graphql.org/draft/#CommentChar
+func (l *Lexer) isCommentChar(r rune) bool {
+	if isLineTerminator(r) {
+		return false
+	}
+	return l.spec == Draft || r >= 0x0020 || r == '\t'
+}
+
+// isStringChar reports whether r, read as size bytes, may appear unescaped in a string or block string.
+// Line terminators are left to the callers. October2021 rejects control characters other than tab,
+// while the draft accepts any SourceCharacter.
+// https://spec.graphql.org/October2021/#StringCharacter
+// https://spec.graphql.org/draft/#StringCharacter
+func (l *Lexer) isStringChar(r rune, size int) bool {
+	if l.spec == Draft {
+		return l.isSourceCharacter(r, size)
+	}
+	return r >= 0x0020 || r == '\t'
+}
@@ -0,0 +1,102 @@
+package gogqllexer
+
+import (
+	"io"
+	"strings"
+)
+
+// State is a snapshot of a Lexer taken by Lexer.State, from which Resume continues lexing the same document.
+// It only holds plain values, so it can be serialized, e.g. as JSON, and restored in another process.
+type State struct {
+	Offset      int `json:"offset"`
+	Line        int `json:"line"`
+	Column      int `json:"column"`
+	UTF16Column int `json:"utf16Column"`
+	// AfterCR reports whether the input before the state ends with '\r',
+	// so that a '\n' right after it does not start another line.
+	AfterCR bool `json:"afterCR,omitempty"`
+	// Tokens is the number of tokens other than EOF in the document before the state.
+	Tokens int `json:"tokens"`
+	// Lexeme is the input already read after the state. It starts with the last token scanned,
+	// which may continue in input that has not been read yet.
+	Lexeme string `json:"lexeme"`
+}
+
+// State returns the state at the start of the last token scanned, or at the start of the input if there is none,
+// with the input read from there on as Lexeme.
+//
+// To lex a document that arrives in chunks, lex a chunk until EOF, take its State and Resume it with the next chunk.
+// The last token before EOF is lexed again from Lexeme because the end of the chunk may have cut it short,
+// so only the first State.Tokens tokens of the document are final.
+// Chunks must be split at rune boundaries.
+func (l *Lexer) State() State {
+	return State{
+		Offset:      l.lexemeCursor.Offset,
+		Line:        l.lexemeCursor.Line,
+		Column:      l.lexemeCursor.Column,
+		UTF16Column: l.lexemeCursor.UTF16Column,
+		AfterCR:     l.lexemeCursor.last == '\r',
+		Tokens:      l.lexemeScanned,
+		Lexeme:      l.lexemeText(),
+	}
+}
+
+// Resume returns a Lexer that continues lexing a document from s, reading s.Lexeme and then scanner.
+// The zero State resumes at the start of a document.
+func Resume(s State, scanner io.RuneScanner, opts ...Option) *Lexer {
+	l := New(&prefixScanner{
+		prefix:      strings.NewReader(s.Lexeme),
+		RuneScanner: scanner,
+	}, opts...)
+	if s.Line == 0 {
+		return l
+	}
+
+	c := cursor{
+		Location: Location{
+			Offset:      s.Offset,
+			Line:        s.Line,
+			Column:      s.Column,
+			UTF16Column: s.UTF16Column,
+		},
+	}
+	if s.AfterCR {
+		c.last = '\r'
+	}
+	l.cur = c
+	l.prev = c
+	l.line = c.Line
+	l.startByteIndex = c.Offset
+	l.lexemeStart = c.Offset
+	l.lexemeCursor = c
+	l.lexemeScanned = s.Tokens
+	l.scanned = s.Tokens
+
+	return l
+}
+
+// prefixScanner reads prefix before RuneScanner.
+type prefixScanner struct {
+	prefix *strings.Reader
+	io.RuneScanner
+
+	// fromPrefix reports whether the last rune read came from prefix.
+	fromPrefix bool
+}
+
+func (s *prefixScanner) ReadRune() (rune, int, error) {
+	if s.prefix.Len() > 0 {
+		s.fromPrefix = true
+		return s.prefix.ReadRune()
+	}
+	s.fromPrefix = false
+
+	return s.RuneScanner.ReadRune()
+}
+
+func (s *prefixScanner) UnreadRune() error {
+	if s.fromPrefix {
+		return s.prefix.UnreadRune()
+	}
+	return s.RuneScanner.UnreadRune()
+}
@@ -0,0 +1,93 @@
+package gogqllexer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_State(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts []Option
+	}{
+		{
+			name: "query",
+			src:  "query Q($id: ID! = -1.5e3) {\r\n  a: f(id: $id, s: \"s\\u00e9\") @d { ...F }\r\n}\n",
+		},
+		{
+			name: "block string and comments",
+			src:  "# c1\n\"\"\"\r\n  block \\\"\"\" \"\"\r\n\"\"\" type T {\n  \"\" f: Int # c2\n}",
+			opts: []Option{WithComments()},
+		},
+		{
+			name: "errors with recovery",
+			src:  "{ a(x: 01, y: \"\U0001F600\\q\") }\r\n\"open\r\n b . c }",
+			opts: []Option{WithErrorRecovery()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := lexAll(New(strings.NewReader(tt.src), tt.opts...))
+
+			for i := 0; i <= len(tt.src); i++ {
+				if !utf8.RuneStart(tt.src[i%len(tt.src)]) {
+					continue
+				}
+
+				l := New(strings.NewReader(tt.src[:i]), tt.opts...)
+				first := lexAll(l)
+
+				// the state survives serialization
+				b, err := json.Marshal(l.State())
+				assert.NoError(t, err)
+				var state State
+				assert.NoError(t, json.Unmarshal(b, &state))
+
+				rest := lexAll(Resume(state, strings.NewReader(tt.src[i:]), tt.opts...))
+
+				got := append(first[:state.Tokens:state.Tokens], rest...)
+				if !assert.Equal(t, want, got, "split at %d", i) {
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestLexer_State_Chunks(t *testing.T) {
+	src := "query Q {\n  alpha(s: \"\"\"a\n  b\"\"\")\n  beta # comment\n  ...F\n}\n"
+	want := lexAll(New(strings.NewReader(src)))
+
+	var (
+		state State
+		got   []Token
+	)
+	for i := 0; i < len(src); i += 3 {
+		end := i + 3
+		if end > len(src) {
+			end = len(src)
+		}
+
+		l := Resume(state, strings.NewReader(src[i:end]))
+		got = append(got, lexAll(l)...)
+		if end == len(src) {
+			break
+		}
+		// drop EOF and the token the end of the chunk may have cut short
+		state = l.State()
+		got = got[:state.Tokens]
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestResume_ZeroState(t *testing.T) {
+	src := "{ a }"
+
+	assert.Equal(t, lexAll(New(strings.NewReader(src))), lexAll(Resume(State{}, strings.NewReader(src))))
+}
@@ -0,0 +1,88 @@
+package gogqllexer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+)
+
+// All lexes src and returns its tokens, excluding EOF.
+// It stops at the first Invalid token and returns the tokens before it with its *LexError.
+func All(src string, opts ...Option) ([]Token, error) {
+	l := NewFromString(src, opts...)
+
+	var tokens []Token
+	for {
+		t := l.NextToken()
+		switch t.Kind {
+		case EOF:
+			return tokens, nil
+		case Invalid:
+			return tokens, l.Err()
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// Stream lexes r in a new goroutine and sends its tokens, excluding EOF, on the returned channel.
+// The token channel is closed at the end of input, after an Invalid token or when ctx is done.
+// The error channel then receives the *LexError, the read error or ctx.Err() if any, and is closed.
+// A Read blocked on r is not interrupted by ctx.
+func Stream(ctx context.Context, r io.Reader, opts ...Option) (<-chan Token, <-chan error) {
+	tokens := make(chan Token, 64)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(tokens)
+
+		scanner := &readErrorScanner{
+			Reader: bufio.NewReader(r),
+		}
+		l := New(scanner, opts...)
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+
+			t := l.NextToken()
+			if scanner.err != nil {
+				errs <- scanner.err
+				return
+			}
+			if t.Kind == EOF {
+				return
+			}
+
+			select {
+			case tokens <- t:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+			if t.Kind == Invalid && !l.recoverErrors {
+				errs <- l.Err()
+				return
+			}
+		}
+	}()
+
+	return tokens, errs
+}
+
+// readErrorScanner records read errors other than io.EOF,
+// which the lexer otherwise treats as the end of input.
+type readErrorScanner struct {
+	*bufio.Reader
+	err error
+}
+
+func (s *readErrorScanner) ReadRune() (rune, int, error) {
+	r, size, err := s.Reader.ReadRune()
+	if err != nil && !errors.Is(err, io.EOF) && s.err == nil {
+		s.err = err
+	}
+	return r, size, err
+}
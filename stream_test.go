@@ -0,0 +1,99 @@
+package gogqllexer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	got, err := All("{ a }")
+	assert.NoError(t, err)
+	assert.Equal(t, []Kind{BraceL, Name, BraceR}, kindsOf(got))
+
+	got, err = All("{ a 01 }")
+	var lexErr *LexError
+	assert.ErrorAs(t, err, &lexErr)
+	assert.Equal(t, ErrLeadingZero, lexErr.Code)
+	assert.Equal(t, []Kind{BraceL, Name}, kindsOf(got))
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []Kind
+		wantErr error
+	}{
+		{
+			name: "all tokens",
+			r:    strings.NewReader("{ a }"),
+			want: []Kind{BraceL, Name, BraceR},
+		},
+		{
+			name:    "invalid token",
+			r:       strings.NewReader("{ ? }"),
+			want:    []Kind{BraceL, Invalid},
+			wantErr: &LexError{},
+		},
+		{
+			name:    "read error",
+			r:       io.MultiReader(strings.NewReader("{ a"), &errorReader{err: errors.New("connection reset")}),
+			want:    []Kind{BraceL},
+			wantErr: errors.New("connection reset"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, errs := Stream(context.Background(), tt.r)
+
+			got := make([]Kind, 0)
+			for tok := range tokens {
+				got = append(got, tok.Kind)
+			}
+			err := <-errs
+
+			assert.Equal(t, tt.want, got)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				assert.NoError(t, err)
+			case *LexError:
+				assert.ErrorAs(t, err, &want)
+			default:
+				assert.EqualError(t, err, want.Error())
+			}
+		})
+	}
+}
+
+func TestStream_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tokens, errs := Stream(ctx, strings.NewReader(strings.Repeat("a ", 10000)))
+
+	<-tokens
+	cancel()
+	for range tokens {
+	}
+
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func kindsOf(tokens []Token) []Kind {
+	kinds := make([]Kind, 0, len(tokens))
+	for _, t := range tokens {
+		kinds = append(kinds, t.Kind)
+	}
+	return kinds
+}
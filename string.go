@@ -0,0 +1,226 @@
+package gogqllexer
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// StringValue returns the value represented by a String or BlockString token,
+// with escape sequences decoded and block string indentation removed.
+// The raw source text stays available in Value.
+func (t Token) StringValue() (string, error) {
+	switch t.Kind {
+	case String:
+		return decodeString(t.Value)
+	case BlockString:
+		return decodeBlockString(t.Value)
+	default:
+		return "", fmt.Errorf("token is not a string value")
+	}
+}
+
+// https://spec.graphql.org/October2021/#sec-String-Value.Semantics
+func decodeString(raw string) (string, error) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", fmt.Errorf("malformed string value %q", raw)
+	}
+	raw = raw[1 : len(raw)-1]
+
+	var b strings.Builder
+	b.Grow(len(raw))
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if i+1 >= len(raw) {
+			return "", fmt.Errorf("unterminated escape sequence")
+		}
+
+		switch raw[i+1] {
+		case '"':
+			b.WriteByte('"')
+		case '\\':
+			b.WriteByte('\\')
+		case '/':
+			b.WriteByte('/')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, n, err := decodeEscapedUnicode(raw[i:])
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+			i += n
+			continue
+		default:
+			return "", fmt.Errorf(`invalid escape sequence "\%c"`, raw[i+1])
+		}
+		i += 2
+	}
+
+	return b.String(), nil
+}
+
+// decodeEscapedUnicode decodes the \uXXXX or \u{X...} escape sequence at the start of s,
+// combining a surrogate pair written as two consecutive \uXXXX sequences.
+// It returns the decoded rune and the number of bytes consumed.
+// https://spec.graphql.org/draft/#EscapedUnicode
+func decodeEscapedUnicode(s string) (rune, int, error) {
+	r, n, ok := readEscapedUnicode(s)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence %q", prefix(s, 6))
+	}
+
+	if utf16.IsSurrogate(r) {
+		if r < 0xDC00 {
+			if low, m, ok := readEscapedUnicode(s[n:]); ok {
+				if pair := utf16.DecodeRune(r, low); pair != utf8.RuneError {
+					return pair, n + m, nil
+				}
+			}
+		}
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence %q, unpaired surrogate", s[:n])
+	}
+	if !utf8.ValidRune(r) {
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence %q", s[:n])
+	}
+
+	return r, n, nil
+}
+
+func readEscapedUnicode(s string) (rune, int, bool) {
+	if !strings.HasPrefix(s, `\u`) {
+		return 0, 0, false
+	}
+
+	// variable-width form \u{X...}
+	if strings.HasPrefix(s, `\u{`) {
+		end := strings.IndexByte(s, '}')
+		if end < 4 {
+			return 0, 0, false
+		}
+		r, ok := parseHex(s[3:end])
+		if !ok || r > utf8.MaxRune {
+			return 0, 0, false
+		}
+		return r, end + 1, true
+	}
+
+	if len(s) < 6 {
+		return 0, 0, false
+	}
+	r, ok := parseHex(s[2:6])
+	if !ok {
+		return 0, 0, false
+	}
+
+	return r, 6, true
+}
+
+func parseHex(s string) (rune, bool) {
+	var r rune
+	for _, c := range s {
+		if !isHexDigit(c) {
+			return 0, false
+		}
+		switch {
+		case isDigit(c):
+			r = r<<4 | (c - '0')
+		case 'a' <= c && c <= 'f':
+			r = r<<4 | (c - 'a' + 10)
+		default:
+			r = r<<4 | (c - 'A' + 10)
+		}
+		if r > utf8.MaxRune {
+			return 0, false
+		}
+	}
+
+	return r, true
+}
+
+func prefix(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
+
+// https://spec.graphql.org/October2021/#BlockStringValue()
+func decodeBlockString(raw string) (string, error) {
+	if len(raw) < 6 || !strings.HasPrefix(raw, `"""`) || !strings.HasSuffix(raw, `"""`) {
+		return "", fmt.Errorf("malformed block string value %q", raw)
+	}
+	raw = strings.ReplaceAll(raw[3:len(raw)-3], `\"""`, `"""`)
+
+	lines := splitLines(raw)
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhiteSpace(line)
+		if indent < len(line) && (commonIndent < 0 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < commonIndent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && leadingWhiteSpace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhiteSpace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// splitLines splits s at every LineTerminator, treating "\r\n" as one terminator.
+func splitLines(s string) []string {
+	lines := make([]string, 0, strings.Count(s, "\n")+1)
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\r':
+			lines = append(lines, s[start:i])
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+			start = i + 1
+		case '\n':
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(lines, s[start:])
+}
+
+func leadingWhiteSpace(s string) int {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,127 @@
+package gogqllexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken_StringValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "simple string",
+			src:  `"simple"`,
+			want: "simple",
+		},
+		{
+			name: "escaped characters",
+			src:  `"\" \\ \/ \b \f \n \r \t"`,
+			want: "\" \\ / \b \f \n \r \t",
+		},
+		{
+			name: "escaped unicode",
+			src:  `"\u00e9\u0041"`,
+			want: "éA",
+		},
+		{
+			name: "escaped surrogate pair",
+			src:  `"\uD83D\uDE00"`,
+			want: "😀",
+		},
+		{
+			name:    "unpaired surrogate",
+			src:     `"\uD83D"`,
+			wantErr: true,
+		},
+		{
+			name:    "unpaired low surrogate",
+			src:     `"\uDE00\uD83D"`,
+			wantErr: true,
+		},
+		{
+			name: "block string",
+			src:  "\"\"\"\n    Hello,\n      World!\n\n    Yours,\n      GraphQL.\n  \"\"\"",
+			want: "Hello,\n  World!\n\nYours,\n  GraphQL.",
+		},
+		{
+			name: "block string keeps first line indentation",
+			src:  "\"\"\"  first\r\n    second\r    third\"\"\"",
+			want: "  first\nsecond\nthird",
+		},
+		{
+			name: "block string escaped triple quote",
+			src:  `"""a \""" "b" \n"""`,
+			want: `a """ "b" \n`,
+		},
+		{
+			name: "empty block string",
+			src:  `""""""`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tok := New(strings.NewReader(tt.src)).NextToken()
+
+			got, err := tok.StringValue()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeString_VariableWidthUnicode(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "basic multilingual plane",
+			raw:  `"\u{41}\u{00e9}"`,
+			want: "Aé",
+		},
+		{
+			name: "supplementary plane",
+			raw:  `"\u{1F600}"`,
+			want: "😀",
+		},
+		{
+			name:    "out of range",
+			raw:     `"\u{110000}"`,
+			wantErr: true,
+		},
+		{
+			name:    "surrogate",
+			raw:     `"\u{D800}"`,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			raw:     `"\u{}"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeString(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
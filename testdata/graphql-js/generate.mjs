@@ -0,0 +1,72 @@
+// Regenerates lexer.json with the tokens and errors of graphql-js for the sources it contains.
+// With --check it writes nothing and fails if lexer.json differs from what it would write.
+//
+//	cd testdata/graphql-js
+//	npm install
+//	node generate.mjs [--check]
+import { readFileSync, writeFileSync } from 'node:fs';
+import { Lexer, Source, TokenKind, version } from 'graphql';
+
+const pinned = JSON.parse(readFileSync(new URL('./package.json', import.meta.url), 'utf8')).dependencies.graphql;
+if (version !== pinned) {
+  throw new Error(`graphql ${version} is installed, lexer.json is generated with graphql ${pinned}`);
+}
+
+const file = new URL('./lexer.json', import.meta.url);
+const cases = JSON.parse(readFileSync(file, 'utf8'));
+
+function lex(source) {
+  const lexer = new Lexer(new Source(source));
+  const tokens = [];
+  let error;
+  try {
+    // advance skips comments
+    for (let token = lexer.advance(); ; token = lexer.advance()) {
+      const { kind, start, end, line, column, value } = token;
+      tokens.push({ kind, start, end, line, column, value });
+      if (token.kind === TokenKind.EOF) {
+        break;
+      }
+    }
+  } catch (e) {
+    const [{ line, column }] = e.locations;
+    error = { message: e.message, line, column };
+  }
+  return { tokens, error };
+}
+
+// dump writes value as JSON on one line, escaping non-ASCII characters.
+function dump(value) {
+  if (typeof value !== 'object') {
+    return JSON.stringify(value).replace(
+      /[\u0080-\uffff]/g,
+      (c) => `\\u${c.charCodeAt(0).toString(16).padStart(4, '0')}`,
+    );
+  }
+  const entries = Object.entries(value).filter(([, v]) => v !== undefined);
+  return `{${entries.map(([k, v]) => `${dump(k)}: ${dump(v)}`).join(', ')}}`;
+}
+
+function format(results) {
+  const blocks = results.map(({ source, tokens, error }) => {
+    const lines = ['  {', `    "source": ${dump(source)},`];
+    const list = tokens.map((token) => `      ${dump(token)}`).join(',\n');
+    lines.push(`    "tokens": [${list ? `\n${list}\n    ]` : ']'}${error ? ',' : ''}`);
+    if (error) {
+      lines.push(`    "error": ${dump(error)}`);
+    }
+    lines.push('  }');
+    return lines.join('\n');
+  });
+  return `[\n${blocks.join(',\n')}\n]\n`;
+}
+
+const generated = format(cases.map(({ source }) => ({ source, ...lex(source) })));
+if (process.argv.includes('--check')) {
+  if (readFileSync(file, 'utf8') !== generated) {
+    console.error(`lexer.json is not the output of graphql ${version}, run node generate.mjs`);
+    process.exit(1);
+  }
+} else {
+  writeFileSync(file, generated);
+}
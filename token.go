@@ -24,6 +24,7 @@ const (
 	Float
 	String
 	BlockString
+	Comment
 )
 
 type Position struct {
@@ -31,8 +32,27 @@ type Position struct {
 	Start int
 }
 
+// Location is a point in the source text.
+// Offset is a 0-based byte offset, Line and both columns are 1-based.
+type Location struct {
+	Offset      int
+	Line        int
+	Column      int
+	UTF16Column int
+}
+
+// Span is the half-open range [Start, End) of source text covered by a token.
+type Span struct {
+	Start Location
+	End   Location
+}
+
 type Token struct {
 	Kind     Kind
 	Value    string
 	Position Position
+	Span     Span
+	// NonSpec reports that the token is only accepted because of a lenient lexer option,
+	// such as a Name containing Unicode letters with WithUnicodeNames.
+	NonSpec bool
 }